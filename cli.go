@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"panda/internal/gamemode"
+	"panda/internal/history"
+	"panda/internal/paths"
+	"panda/internal/pomodoro"
+)
+
+// command is one `panda <name>` subcommand.
+type command struct {
+	name, args, summary string
+	run                 func(p paths.Paths, args []string) error
+}
+
+var commands = []command{
+	{"run", "[--api ADDR] [--assets DIR]", "open the Panda Focus window (default)", cmdRun},
+	{"focus", "[--minutes N] [--sessions N]", "run the focus timer in the terminal", cmdFocus},
+	{"stats", "[--json] [--days N]", "print play and focus statistics", cmdStats},
+	{"reset-today", "", "clear today's play time and Panda-Man wins", cmdResetToday},
+	{"export", "[--out FILE]", "write stats, settings and focus history as JSON", cmdExport},
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "usage: panda [--data-dir DIR] [command] [flags]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %-30s %s\n", c.name, c.args, c.summary)
+	}
+	fmt.Fprintf(w, "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+// runCommand dispatches args (without the program name) to a subcommand.
+func runCommand(p paths.Paths, args []string) error {
+	name := "run"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(p, args)
+		}
+	}
+	usage()
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func cmdRun(p paths.Paths, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var opts runOptions
+	fs.StringVar(&opts.APIAddr, "api", "", "serve the control API on this loopback address, e.g. 127.0.0.1:7777")
+	fs.StringVar(&opts.AssetDir, "assets", os.Getenv("PANDA_ASSETS"), "dev mode: load assets from this directory (e.g. internal/assets) and reload them when they change (env PANDA_ASSETS)")
+	fs.Parse(args)
+	return runGame(p, opts)
+}
+
+// cmdFocus runs Pomodoro work sessions without a window. Breaks between
+// sessions start on their own; Ctrl+C abandons the current session.
+func cmdFocus(p paths.Paths, args []string) error {
+	fs := flag.NewFlagSet("focus", flag.ExitOnError)
+	minutes := fs.Int("minutes", 0, "work session length (default from settings)")
+	sessions := fs.Int("sessions", 1, "work sessions to complete before exiting")
+	fs.Parse(args)
+
+	settings := peekSettings(newSettingsFile(p.Settings()))
+	cfg := settings.Focus
+	if *minutes > 0 {
+		cfg.WorkMinutes = *minutes
+	}
+	cfg.AutoStart = true
+
+	timer := pomodoro.New(cfg)
+	timer.Subscribe(history.NewRecorder(history.Open(p.History())).Handle)
+	completed := 0
+	timer.Subscribe(func(ev pomodoro.Event) {
+		if ev.Kind == pomodoro.EventCompleted && ev.Phase == pomodoro.PhaseWork {
+			completed++
+			fmt.Printf("\rsession %d/%d done!%20s\n", completed, *sessions, "")
+		}
+	})
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(time.Second / 4)
+	defer ticker.Stop()
+
+	timer.Start(time.Now())
+	for completed < *sessions {
+		select {
+		case <-interrupt:
+			timer.Reset(time.Now())
+			fmt.Println()
+			return errors.New("focus session abandoned")
+		case now := <-ticker.C:
+			timer.Update(now)
+			if completed < *sessions {
+				fmt.Printf("\r%-12s %s  ", phaseLabel(timer.Phase), formatClock(timer.Remaining))
+			}
+		}
+	}
+	return nil
+}
+
+func phaseLabel(ph pomodoro.Phase) string {
+	return strings.ToUpper(strings.ReplaceAll(ph.String(), "_", " "))
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// statsReport is the `panda stats --json` output.
+type statsReport struct {
+	TotalPlayMinutes      int64      `json:"total_play_minutes"`
+	TodayPlayMinutes      int64      `json:"today_play_minutes"`
+	FishCaught            int        `json:"fish_caught"`
+	PacmanWinsToday       int        `json:"pacman_wins_today"`
+	PacmanHighScore       int        `json:"pacman_high_score"`
+	PacmanEndlessBest     int        `json:"pacman_endless_best"`
+	GhostsEaten           int        `json:"ghosts_eaten"`
+	CompletedSessions     int        `json:"completed_sessions"`
+	TotalFocusMinutes     int        `json:"total_focus_minutes"`
+	AverageSessionMinutes int        `json:"average_session_minutes"`
+	CurrentStreak         int        `json:"current_streak_days"`
+	LongestStreak         int        `json:"longest_streak_days"`
+	Days                  []dayEntry `json:"days"`
+}
+
+type dayEntry struct {
+	Date         string `json:"date"`
+	FocusMinutes int    `json:"focus_minutes"`
+	Completed    int    `json:"completed"`
+}
+
+func cmdStats(p paths.Paths, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print JSON instead of text")
+	days := fs.Int("days", 7, "days of focus history to list")
+	fs.Parse(args)
+
+	now := time.Now()
+	stats := peekStats(newStatsFile(p.Stats()), now)
+	sessions, err := history.Open(p.History()).AllSessions()
+	if err != nil {
+		return err
+	}
+	sum := history.Summarize(sessions, now, max(*days, 1))
+
+	r := statsReport{
+		TotalPlayMinutes:      stats.TotalPlayTimeSec / 60,
+		TodayPlayMinutes:      stats.TodayPlayTimeSec / 60,
+		FishCaught:            stats.FishCaught,
+		PacmanWinsToday:       stats.PacmanWinsToday,
+		PacmanHighScore:       stats.PacmanHighScore,
+		PacmanEndlessBest:     stats.PacmanEndlessBest,
+		GhostsEaten:           stats.GhostsEaten,
+		CompletedSessions:     sum.Completed,
+		TotalFocusMinutes:     int(sum.Total.Minutes()),
+		AverageSessionMinutes: int(sum.AverageSession.Minutes()),
+		CurrentStreak:         sum.CurrentStreak,
+		LongestStreak:         sum.LongestStreak,
+	}
+	for _, d := range sum.Days {
+		r.Days = append(r.Days, dayEntry{d.Day.Format("2006-01-02"), int(d.Focused.Minutes()), d.Completed})
+	}
+
+	if *asJSON {
+		return writeJSON(os.Stdout, r)
+	}
+	fmt.Printf("play time   today %dm, total %dm\n", r.TodayPlayMinutes, r.TotalPlayMinutes)
+	fmt.Printf("fish caught %d, panda-man wins today %d\n", r.FishCaught, r.PacmanWinsToday)
+	fmt.Printf("panda-man   high score %d, endless best %d boards, gophers eaten %d\n", r.PacmanHighScore, r.PacmanEndlessBest, r.GhostsEaten)
+	fmt.Printf("focus       %d sessions, %dm total, %dm average\n", r.CompletedSessions, r.TotalFocusMinutes, r.AverageSessionMinutes)
+	fmt.Printf("streak      %d days (best %d)\n\n", r.CurrentStreak, r.LongestStreak)
+	for _, d := range r.Days {
+		fmt.Printf("%s %4dm %s\n", d.Date, d.FocusMinutes, strings.Repeat("#", d.FocusMinutes/5))
+	}
+	return nil
+}
+
+// cmdResetToday zeroes the daily counters. A running window keeps its own
+// copy of the stats and will write it back on its next save.
+func cmdResetToday(p paths.Paths, args []string) error {
+	fs := flag.NewFlagSet("reset-today", flag.ExitOnError)
+	fs.Parse(args)
+
+	f := newStatsFile(p.Stats())
+	stats := loadStats(f, time.Now())
+	stats.TodayPlayTimeSec = 0
+	stats.PacmanWinsToday = 0
+	return f.Save(stats)
+}
+
+// export is the `panda export` document.
+type export struct {
+	ExportedAt time.Time            `json:"exported_at"`
+	Stats      gamemode.GameStats   `json:"stats"`
+	Settings   gamemode.AppSettings `json:"settings"`
+	Sessions   []history.Entry      `json:"sessions"`
+}
+
+func cmdExport(p paths.Paths, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	out := fs.String("out", "", "write to FILE instead of stdout")
+	fs.Parse(args)
+
+	now := time.Now()
+	settings := peekSettings(newSettingsFile(p.Settings()))
+	sessions, err := history.Open(p.History()).AllSessions()
+	if err != nil {
+		return err
+	}
+	doc := export{
+		ExportedAt: now,
+		Stats:      peekStats(newStatsFile(p.Stats()), now),
+		Settings:   settings,
+		Sessions:   sessions,
+	}
+	if *out == "" {
+		return writeJSON(os.Stdout, doc)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(f, doc); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"panda/internal/api"
+	"panda/internal/gamemode"
+)
+
+// control adapts the game context to the HTTP control API. The api.Server
+// only calls it from Game.Update, so it can use the context freely.
+type control struct {
+	ctx *gamemode.Context
+}
+
+func (c control) Snapshot() api.Snapshot {
+	t := c.ctx.Timer
+	return api.Snapshot{
+		Timer: api.TimerState{
+			Phase:        t.Phase.String(),
+			Status:       t.Status.String(),
+			RemainingSec: t.Remaining.Seconds(),
+			LengthSec:    t.Length().Seconds(),
+			Sessions:     t.Sessions,
+		},
+		Today: api.Today{
+			Date:       c.ctx.Stats.LastLoginDate,
+			PlaySec:    c.ctx.Stats.TodayPlayTimeSec,
+			FishCaught: c.ctx.Stats.FishCaught,
+			PacmanWins: c.ctx.Stats.PacmanWinsToday,
+		},
+		Mode:  gamemode.ModeName(c.ctx.Scenes.Current()),
+		Modes: gamemode.ModeNames,
+	}
+}
+
+func (c control) StartTimer() { c.ctx.Timer.Start(c.ctx.Clock.Now()) }
+func (c control) PauseTimer() { c.ctx.Timer.Pause(c.ctx.Clock.Now()) }
+func (c control) StopTimer()  { c.ctx.Timer.Reset(c.ctx.Clock.Now()) }
+func (c control) SkipTimer()  { c.ctx.Timer.Skip(c.ctx.Clock.Now()) }
+
+func (c control) SwitchMode(name string) error {
+	id, ok := gamemode.ModeByName(name)
+	if !ok {
+		return fmt.Errorf("unknown mode %q", name)
+	}
+	c.ctx.Scenes.Switch(id)
+	return nil
+}
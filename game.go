@@ -1,127 +1,136 @@
 package main
 
 import (
-	"image/color"
+	"log"
+	"math/rand"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	
-	"panda/internal/entity"
-	"panda/internal/gamemode"
-)
 
-// Define Modes
-type GameMode int
-
-const (
-	ModeRelax    GameMode = iota
-	ModeFocus
-	ModeEating
-	ModeMusic
-	ModeMinigame
+	"panda/internal/api"
+	"panda/internal/assets"
+	"panda/internal/audio"
+	"panda/internal/clock"
+	"panda/internal/gamemode"
+	"panda/internal/history"
+	"panda/internal/input"
+	"panda/internal/paths"
+	"panda/internal/save"
 )
 
-// Game holds global state and sub-systems
+// Game owns the save files and hands everything else to the scenes
 type Game struct {
-	CurrentMode GameMode
-	Tick        int
+	ctx      *gamemode.Context
+	lastSave time.Time
 
-	// Entities & Sub-systems
-	Panda *entity.Panda
-	Focus *gamemode.FocusMode
+	statsFile, settingsFile *save.File
+
+	api         *api.Server // nil unless the control API is enabled
+	watchAssets bool        // Dev mode: poll the asset files for edits
 }
 
-// NewGame initializes the state and loads initial assets
-func NewGame() *Game {
-	return &Game{
-		CurrentMode: ModeRelax,
-		Panda:       entity.NewPanda(),       // Loads panda_idle.png
-		Focus:       gamemode.NewFocusMode(), // Sets up 25min timer
+// NewGame loads saved data from p and registers every scene
+func NewGame(p paths.Paths) *Game {
+	ctx := gamemode.NewContext(input.Ebiten, clock.System, rand.New(rand.NewSource(time.Now().UnixNano())))
+	g := &Game{
+		ctx:          ctx,
+		lastSave:     ctx.Clock.Now(),
+		statsFile:    newStatsFile(p.Stats()),
+		settingsFile: newSettingsFile(p.Settings()),
 	}
+	ctx.SaveSettings = func() { g.SaveSettings() }
+	ctx.History = history.Open(p.History())
+	ctx.Audio = audio.New(audio.DefaultVolumes())
+	ctx.Timer.Subscribe(history.NewRecorder(ctx.History).Handle)
+	g.LoadData()
+	ctx.RegisterScenes()
+	return g
 }
 
-// Update: Logic Loop (60 TPS)
-func (g *Game) Update() error {
-	g.Tick++
-
-	// --- GLOBAL INPUT (Mode Switching) ---
-	// Press 1-5 to switch screens
-	if ebiten.IsKeyPressed(ebiten.Key1) { g.CurrentMode = ModeRelax }
-	if ebiten.IsKeyPressed(ebiten.Key2) { g.CurrentMode = ModeFocus }
-	if ebiten.IsKeyPressed(ebiten.Key3) { g.CurrentMode = ModeEating }
-	if ebiten.IsKeyPressed(ebiten.Key4) { g.CurrentMode = ModeMusic }
-	if ebiten.IsKeyPressed(ebiten.Key5) { g.CurrentMode = ModeMinigame }
-
-	// --- MODE SPECIFIC LOGIC ---
-	switch g.CurrentMode {
-	case ModeRelax:
-		// In Relax mode, the Panda wanders/animates freely
-		if g.Panda != nil {
-			g.Panda.Update()
+// ServeAPI starts the HTTP control API on addr (loopback only). Requests
+// are applied on the next Update.
+func (g *Game) ServeAPI(addr string) error {
+	ln, err := api.Listen(addr)
+	if err != nil {
+		return err
+	}
+	g.api = api.NewServer(control{g.ctx}, g.ctx.History, g.ctx.Clock)
+	g.api.Poll()
+	go func() {
+		if err := g.api.Serve(ln); err != nil {
+			log.Printf("api: %v", err)
 		}
+	}()
+	log.Printf("control API listening on http://%s", ln.Addr())
+	return nil
+}
 
-	case ModeFocus:
-		// In Focus mode, update the timer
-		if g.Focus != nil {
-			g.Focus.Update()
-		}
-		// Optional: Still animate the panda (maybe slower?)
-		if g.Panda != nil {
-			g.Panda.Update()
-		}
+// --- IO Logic ---
+
+// LoadData reads stats and settings, writing default settings on first
+// launch.
+func (g *Game) LoadData() {
+	g.ctx.Stats = loadStats(g.statsFile, g.ctx.Clock.Now())
+	settings, found := loadSettings(g.settingsFile)
+	g.ctx.Settings = settings
+	if !found {
+		g.SaveSettings()
+	}
+	g.ctx.ApplySettings()
+}
+
+func (g *Game) SaveSettings() error {
+	err := g.settingsFile.Save(g.ctx.Settings)
+	if err != nil {
+		log.Printf("settings: %v", err)
+	}
+	return err
+}
 
-	case ModeEating:
-		// Placeholder for Eating Logic
-	case ModeMusic:
-		// Placeholder for Music Logic
-	case ModeMinigame:
-		// Placeholder for Minigame Logic
+func (g *Game) SaveStats() error {
+	err := g.statsFile.Save(g.ctx.Stats)
+	if err != nil {
+		log.Printf("stats: %v", err)
 	}
+	return err
+}
 
+// Update: Logic Loop (60 TPS)
+func (g *Game) Update() error {
+	if now := g.ctx.Clock.Now(); now.Sub(g.lastSave) > 10*time.Second {
+		g.SaveStats()
+		g.lastSave = now
+	}
+	if g.watchAssets && g.ctx.Tick%30 == 0 {
+		assets.Default.Poll()
+	}
+	if err := g.ctx.Update(); err != nil {
+		return err
+	}
+	if g.api != nil {
+		g.api.Poll()
+	}
 	return nil
 }
 
 // Draw: Render Loop (VSync)
 func (g *Game) Draw(screen *ebiten.Image) {
-	// 1. Clear Screen (Retro Dark Grey Background)
-	screen.Fill(color.RGBA{0x2b, 0x2b, 0x2b, 0xff})
-
-	// 2. Mode Specific Rendering
-	switch g.CurrentMode {
-	case ModeRelax:
-		// Draw Scene Elements
-		ebitenutil.DebugPrint(screen, "MODE: RELAX (1)\n[Panda is chilling]")
-		
-		if g.Panda != nil {
-			g.Panda.Draw(screen)
-		}
-
-	case ModeFocus:
-		// Draw Focus UI
-		ebitenutil.DebugPrint(screen, "MODE: FOCUS (2)")
-		
-		// Draw Panda in background (optional)
-		if g.Panda != nil {
-			g.Panda.Draw(screen)
-		}
-		
-		// Draw Timer Overlay
-		if g.Focus != nil {
-			g.Focus.Draw(screen)
-		}
+	screen.Fill(g.ctx.BgColor)
+	g.ctx.Draw(screen)
+}
 
-	case ModeEating:
-		ebitenutil.DebugPrint(screen, "MODE: EATING (3)\n(Coming Soon)")
-	case ModeMusic:
-		ebitenutil.DebugPrint(screen, "MODE: MUSIC (4)\n(Coming Soon)")
-	case ModeMinigame:
-		ebitenutil.DebugPrint(screen, "MODE: MINIGAME (5)\n(Coming Soon)")
-	}
+// DrawFinalScreen scales the logical screen up to the window with
+// nearest-neighbour filtering so the pixel art stays crisp.
+func (g *Game) DrawFinalScreen(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = geoM
+	op.Filter = ebiten.FilterNearest
+	screen.DrawImage(offscreen, op)
 }
 
 // Layout: Scaling Strategy
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	// The game logical resolution is 320x240.
 	// Ebiten will automatically scale this up to fit the window.
-	return 320, 240
-}
\ No newline at end of file
+	return g.ctx.Scenes.Layout(outsideWidth, outsideHeight)
+}
@@ -0,0 +1,148 @@
+// Package anim plays named clips from a sprite sheet. A sheet is a PNG
+// plus a JSON manifest next to it in Aseprite's "json-array" export
+// format: every frame's rectangle and duration, and frame tags naming
+// the clips. Playback only deals in frame rectangles and durations, so
+// it runs (and can be tested) without a window.
+package anim
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"strconv"
+	"time"
+)
+
+// Mode says what a clip does when it reaches its last frame.
+type Mode int
+
+const (
+	Loop     Mode = iota // Start over
+	PingPong             // Play backwards to the first frame, then forwards again
+	Once                 // Hold the last frame and report EventEnd
+)
+
+func (m Mode) String() string {
+	return [...]string{"loop", "pingpong", "once"}[m]
+}
+
+func parseMode(s string) (Mode, error) {
+	switch s {
+	case "", "loop", "forward":
+		return Loop, nil
+	case "pingpong":
+		return PingPong, nil
+	case "once":
+		return Once, nil
+	}
+	return 0, fmt.Errorf("unknown clip mode %q", s)
+}
+
+// --- Manifest (Aseprite json-array) ---
+
+type manifest struct {
+	Frames []struct {
+		Frame struct {
+			X int `json:"x"`
+			Y int `json:"y"`
+			W int `json:"w"`
+			H int `json:"h"`
+		} `json:"frame"`
+		Duration int `json:"duration"` // Milliseconds
+	} `json:"frames"`
+	Meta struct {
+		Image     string `json:"image"`
+		FrameTags []struct {
+			Name      string `json:"name"`
+			From      int    `json:"from"`
+			To        int    `json:"to"`
+			Direction string `json:"direction"` // forward, pingpong
+			Repeat    string `json:"repeat"`    // Aseprite: "1" plays once
+
+			// Extensions Aseprite doesn't write; add them by hand.
+			Mode   string            `json:"mode"`   // loop, pingpong, once
+			Events map[string]string `json:"events"` // Clip frame offset -> event name
+		} `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// Clip is a named run of sheet frames.
+type Clip struct {
+	Name   string
+	Frames []int // Indexes into Sheet.Frames
+	Mode   Mode
+
+	// Events fire when playback enters the given clip frame (an index
+	// into Frames, not into the sheet).
+	Events map[int]string
+}
+
+// Sheet is a decoded manifest.
+type Sheet struct {
+	Image     string // PNG file name, relative to the manifest
+	Frames    []image.Rectangle
+	Durations []time.Duration
+	Clips     map[string]*Clip
+}
+
+// Parse decodes a manifest. Frames without a duration get 100ms, as in
+// Aseprite.
+func Parse(data []byte) (*Sheet, error) {
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("anim: %w", err)
+	}
+	if len(m.Frames) == 0 {
+		return nil, fmt.Errorf("anim: manifest has no frames")
+	}
+
+	s := &Sheet{Image: m.Meta.Image, Clips: map[string]*Clip{}}
+	for _, f := range m.Frames {
+		r := f.Frame
+		s.Frames = append(s.Frames, image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H))
+		ms := f.Duration
+		if ms <= 0 {
+			ms = 100
+		}
+		s.Durations = append(s.Durations, time.Duration(ms)*time.Millisecond)
+	}
+
+	for _, t := range m.Meta.FrameTags {
+		if t.From < 0 || t.To >= len(s.Frames) || t.From > t.To {
+			return nil, fmt.Errorf("anim: clip %q: frames %d-%d out of range", t.Name, t.From, t.To)
+		}
+		mode := t.Mode
+		if mode == "" {
+			mode = t.Direction
+			if t.Repeat == "1" {
+				mode = "once"
+			}
+		}
+		c := &Clip{Name: t.Name, Events: map[int]string{}}
+		var err error
+		if c.Mode, err = parseMode(mode); err != nil {
+			return nil, fmt.Errorf("anim: clip %q: %w", t.Name, err)
+		}
+		for i := t.From; i <= t.To; i++ {
+			c.Frames = append(c.Frames, i)
+		}
+		for k, name := range t.Events {
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(c.Frames) {
+				return nil, fmt.Errorf("anim: clip %q: bad event frame %q", t.Name, k)
+			}
+			c.Events[i] = name
+		}
+		s.Clips[t.Name] = c
+	}
+
+	// A sheet without tags is one looping clip of every frame
+	if len(s.Clips) == 0 {
+		c := &Clip{Name: "default", Events: map[int]string{}}
+		for i := range s.Frames {
+			c.Frames = append(c.Frames, i)
+		}
+		s.Clips[c.Name] = c
+	}
+	return s, nil
+}
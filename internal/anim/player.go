@@ -0,0 +1,152 @@
+package anim
+
+import (
+	"image"
+	"time"
+)
+
+// EventEnd is sent when a Once clip reaches its last frame.
+const EventEnd = "end"
+
+// Event is a clip event (from the manifest) or EventEnd.
+type Event struct {
+	Clip  string
+	Name  string
+	Frame int // Clip frame that fired it
+}
+
+// Player runs one clip of a sheet at a time. It has no clock of its own;
+// the owner advances it with Update.
+type Player struct {
+	Sheet *Sheet
+
+	clip    *Clip
+	pos     int // Index into clip.Frames
+	dir     int // +1, or -1 on the way back in PingPong
+	elapsed time.Duration
+	done    bool
+
+	listeners []func(Event)
+}
+
+// NewPlayer starts on the sheet's "idle" clip if it has one.
+func NewPlayer(s *Sheet) *Player {
+	p := &Player{}
+	p.SetSheet(s)
+	return p
+}
+
+// Subscribe registers fn to receive every Event.
+func (p *Player) Subscribe(fn func(Event)) {
+	p.listeners = append(p.listeners, fn)
+}
+
+// Play switches to the named clip, starting from its first frame. Asking
+// for the clip already playing carries on where it is (unless a Once
+// clip has finished, which starts it again), so callers can pass their
+// state every tick. It returns false if the sheet has no such clip.
+func (p *Player) Play(name string) bool {
+	c, ok := p.Sheet.Clips[name]
+	if !ok {
+		return false
+	}
+	if c == p.clip && !p.done {
+		return true
+	}
+	p.clip = c
+	p.dir = 1
+	p.elapsed = 0
+	p.done = false
+	p.enter(0)
+	return true
+}
+
+// SetSheet swaps in a new sheet (e.g. a reloaded manifest), keeping the
+// listeners and restarting the clip of the same name if it still exists.
+func (p *Player) SetSheet(s *Sheet) {
+	name := p.Clip()
+	p.Sheet = s
+	p.clip = nil
+	if !p.Play(name) && !p.Play("idle") {
+		for name := range s.Clips {
+			p.Play(name)
+			break
+		}
+	}
+}
+
+// Clip is the name of the clip playing, "" if none.
+func (p *Player) Clip() string {
+	if p.clip == nil {
+		return ""
+	}
+	return p.clip.Name
+}
+
+// Done reports whether a Once clip has reached its end.
+func (p *Player) Done() bool { return p.done }
+
+// Frame is the sheet rectangle to draw now.
+func (p *Player) Frame() image.Rectangle {
+	if p.clip == nil {
+		return image.Rectangle{}
+	}
+	return p.Sheet.Frames[p.clip.Frames[p.pos]]
+}
+
+// Update advances playback by dt, stepping over as many frames as it
+// covers.
+func (p *Player) Update(dt time.Duration) {
+	if p.clip == nil || p.done {
+		return
+	}
+	p.elapsed += dt
+	for !p.done {
+		d := p.Sheet.Durations[p.clip.Frames[p.pos]]
+		if p.elapsed < d {
+			return
+		}
+		p.elapsed -= d
+		p.step()
+	}
+}
+
+func (p *Player) step() {
+	n := len(p.clip.Frames)
+	next := p.pos + p.dir
+	switch p.clip.Mode {
+	case Loop:
+		if next >= n {
+			next = 0
+		}
+	case PingPong:
+		if n == 1 {
+			return
+		}
+		if next < 0 || next >= n {
+			p.dir = -p.dir
+			next = p.pos + p.dir
+		}
+	case Once:
+		if next >= n {
+			p.done = true
+			p.emit(EventEnd)
+			return
+		}
+	}
+	p.enter(next)
+}
+
+func (p *Player) enter(pos int) {
+	p.pos = pos
+	if name, ok := p.clip.Events[pos]; ok {
+		p.emit(name)
+	}
+}
+
+func (p *Player) emit(name string) {
+	ev := Event{Clip: p.clip.Name, Name: name, Frame: p.pos}
+	for _, fn := range p.listeners {
+		fn(ev)
+	}
+}
@@ -0,0 +1,340 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"panda/internal/clock"
+	"panda/internal/history"
+)
+
+// TimerState is the focus timer as reported over the API.
+type TimerState struct {
+	Phase        string  `json:"phase"`  // work, short_break, long_break
+	Status       string  `json:"status"` // stopped, running, paused
+	RemainingSec float64 `json:"remaining_sec"`
+	LengthSec    float64 `json:"length_sec"`
+	Sessions     int     `json:"sessions"`
+}
+
+// Today is the day's play and focus stats.
+type Today struct {
+	Date              string `json:"date"`
+	PlaySec           int64  `json:"play_sec"`
+	FishCaught        int    `json:"fish_caught"`
+	PacmanWins        int    `json:"pacman_wins"`
+	FocusSec          int64  `json:"focus_sec"`
+	CompletedSessions int    `json:"completed_sessions"`
+}
+
+// Snapshot is everything the game publishes once per tick.
+type Snapshot struct {
+	Timer TimerState `json:"timer"`
+	Today Today      `json:"today"`
+	Mode  string     `json:"mode"`
+	Modes []string   `json:"modes"`
+}
+
+// Backend is the running game. Its methods are only ever called from
+// Poll, i.e. on the game's own goroutine, so it needs no locking.
+type Backend interface {
+	Snapshot() Snapshot
+	StartTimer()
+	PauseTimer()
+	StopTimer()
+	SkipTimer()
+	SwitchMode(name string) error
+}
+
+type command struct {
+	fn   func() error
+	done chan error
+}
+
+// Server exposes a Backend over HTTP. Handlers never touch game state:
+// reads come from the last published Snapshot and writes are queued for
+// the next Poll.
+type Server struct {
+	backend Backend
+	history *history.Log // Optional; adds focus totals to /api/today
+	clock   clock.Clock  // Picks the day /api/today reports
+
+	cmds chan command
+
+	mu   sync.Mutex
+	snap Snapshot
+	subs map[chan Snapshot]struct{}
+}
+
+func NewServer(b Backend, h *history.Log, clk clock.Clock) *Server {
+	return &Server{
+		backend: b,
+		history: h,
+		clock:   clk,
+		cmds:    make(chan command, 16),
+		subs:    map[chan Snapshot]struct{}{},
+	}
+}
+
+// Poll runs queued commands and publishes a fresh snapshot. Call it once
+// per tick from the game loop.
+func (s *Server) Poll() {
+	for done := false; !done; {
+		select {
+		case c := <-s.cmds:
+			c.done <- c.fn()
+		default:
+			done = true
+		}
+	}
+
+	snap := s.backend.Snapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed := snap.Mode != s.snap.Mode ||
+		snap.Timer.Phase != s.snap.Timer.Phase ||
+		snap.Timer.Status != s.snap.Timer.Status ||
+		int(snap.Timer.RemainingSec) != int(s.snap.Timer.RemainingSec)
+	s.snap = snap
+	if !changed {
+		return
+	}
+	for ch := range s.subs {
+		select {
+		case <-ch: // Drop the stale one; listeners only want the latest
+		default:
+		}
+		ch <- snap
+	}
+}
+
+func (s *Server) snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snap
+}
+
+// do queues fn for the game goroutine and waits for it to run.
+func (s *Server) do(ctx context.Context, fn func() error) error {
+	c := command{fn: fn, done: make(chan error, 1)}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	select {
+	case s.cmds <- c:
+	case <-ctx.Done():
+		return errors.New("api: game is busy")
+	}
+	select {
+	case err := <-c.done:
+		return err
+	case <-ctx.Done():
+		return errors.New("api: game did not respond")
+	}
+}
+
+// Handler returns the API routes, wrapped so only loopback clients are
+// served.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/timer", s.getTimer)
+	mux.HandleFunc("POST /api/timer/start", s.timerCommand(s.backend.StartTimer))
+	mux.HandleFunc("POST /api/timer/pause", s.timerCommand(s.backend.PauseTimer))
+	mux.HandleFunc("POST /api/timer/stop", s.timerCommand(s.backend.StopTimer))
+	mux.HandleFunc("POST /api/timer/skip", s.timerCommand(s.backend.SkipTimer))
+	mux.HandleFunc("GET /api/today", s.getToday)
+	mux.HandleFunc("GET /api/mode", s.getMode)
+	mux.HandleFunc("POST /api/mode", s.postMode)
+	mux.HandleFunc("GET /api/events", s.events)
+	return localOnly(mux)
+}
+
+// Listen opens addr for the API. It must be a loopback address such as
+// 127.0.0.1:7777 so the timer is never exposed to the network.
+func Listen(addr string) (net.Listener, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !isLoopback(host) {
+		return nil, fmt.Errorf("api: refusing to listen on non-loopback address %q", addr)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// Serve answers API requests on ln until it is closed.
+func (s *Server) Serve(ln net.Listener) error {
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	return srv.Serve(ln)
+}
+
+func (s *Server) getTimer(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.snapshot().Timer)
+}
+
+func (s *Server) timerCommand(fn func()) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var state TimerState
+		err := s.do(r.Context(), func() error {
+			fn()
+			state = s.backend.Snapshot().Timer // The state the command left
+			return nil
+		})
+		if err != nil {
+			writeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, state)
+	}
+}
+
+func (s *Server) getToday(w http.ResponseWriter, r *http.Request) {
+	today := s.snapshot().Today
+	if s.history != nil {
+		sessions, err := s.history.Day(s.clock.Now())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, e := range sessions {
+			today.FocusSec += e.ActualSec
+			if e.Outcome == history.OutcomeCompleted {
+				today.CompletedSessions++
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, today)
+}
+
+type modeBody struct {
+	Mode  string   `json:"mode"`
+	Modes []string `json:"modes,omitempty"`
+}
+
+func (s *Server) getMode(w http.ResponseWriter, r *http.Request) {
+	snap := s.snapshot()
+	writeJSON(w, http.StatusOK, modeBody{Mode: snap.Mode, Modes: snap.Modes})
+}
+
+func (s *Server) postMode(w http.ResponseWriter, r *http.Request) {
+	var body modeBody
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var switchErr error
+	err := s.do(r.Context(), func() error {
+		switchErr = s.backend.SwitchMode(body.Mode)
+		return nil
+	})
+	switch {
+	case err != nil:
+		writeError(w, http.StatusServiceUnavailable, err)
+	case switchErr != nil:
+		writeError(w, http.StatusBadRequest, switchErr)
+	default:
+		writeJSON(w, http.StatusOK, modeBody{Mode: body.Mode})
+	}
+}
+
+// events streams the snapshot as Server-Sent Events: one "tick" event
+// whenever the timer's whole second, phase, status or the mode changes.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("api: streaming unsupported"))
+		return
+	}
+	ch := make(chan Snapshot, 1)
+	ch <- s.snapshot()
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case snap := <-ch:
+			d, err := json.Marshal(snap)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: tick\ndata: %s\n\n", d); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// localOnly rejects anything but loopback clients, and browser requests
+// from other origins (a web page can't drive the timer via DNS
+// rebinding or a cross-site POST).
+func localOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil || !isLoopback(host) {
+			writeError(w, http.StatusForbidden, errors.New("api: loopback clients only"))
+			return
+		}
+		if !isLocalHost(r.Host) {
+			writeError(w, http.StatusForbidden, errors.New("api: unexpected Host header"))
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !isLocalOrigin(origin) {
+			writeError(w, http.StatusForbidden, errors.New("api: cross-origin requests not allowed"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func isLocalHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport // No port
+	}
+	return isLoopback(host)
+}
+
+func isLocalOrigin(origin string) bool {
+	for _, scheme := range []string{"http://", "https://"} {
+		if len(origin) > len(scheme) && origin[:len(scheme)] == scheme {
+			return isLocalHost(origin[len(scheme):])
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
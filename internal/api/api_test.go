@@ -0,0 +1,217 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"panda/internal/clock"
+	"panda/internal/history"
+)
+
+// fakeBackend records the commands it runs. It is only touched from the
+// test goroutine, through Poll.
+type fakeBackend struct {
+	snap  Snapshot
+	calls []string
+}
+
+func (b *fakeBackend) Snapshot() Snapshot { return b.snap }
+func (b *fakeBackend) StartTimer()        { b.calls = append(b.calls, "start") }
+func (b *fakeBackend) PauseTimer()        { b.calls = append(b.calls, "pause") }
+func (b *fakeBackend) StopTimer()         { b.calls = append(b.calls, "stop") }
+func (b *fakeBackend) SkipTimer()         { b.calls = append(b.calls, "skip") }
+
+func (b *fakeBackend) SwitchMode(name string) error {
+	b.calls = append(b.calls, "mode "+name)
+	return nil
+}
+
+func newTestServer(t *testing.T) (*Server, *fakeBackend) {
+	t.Helper()
+	b := &fakeBackend{snap: Snapshot{
+		Timer: TimerState{Phase: "work", Status: "stopped", RemainingSec: 1500, LengthSec: 1500},
+		Mode:  "focus",
+		Modes: []string{"focus", "pacman"},
+	}}
+	s := NewServer(b, nil, clock.NewManual(time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)))
+	s.Poll()
+	return s, b
+}
+
+// localRequest is a request from a loopback client to the usual address.
+func localRequest(method, path, body string) *http.Request {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.RemoteAddr = "127.0.0.1:52000"
+	r.Host = "127.0.0.1:7777"
+	return r
+}
+
+func TestLocalOnly(t *testing.T) {
+	tests := []struct {
+		name, remote, host, origin string
+		want                       int
+	}{
+		{"loopback", "127.0.0.1:52000", "127.0.0.1:7777", "", http.StatusOK},
+		{"localhost", "[::1]:52000", "localhost:7777", "http://localhost:7777", http.StatusOK},
+		{"remote client", "192.0.2.1:52000", "127.0.0.1:7777", "", http.StatusForbidden},
+		{"foreign Host", "127.0.0.1:52000", "evil.example", "", http.StatusForbidden},
+		{"foreign Host with port", "127.0.0.1:52000", "evil.example:7777", "", http.StatusForbidden},
+		{"foreign Origin", "127.0.0.1:52000", "127.0.0.1:7777", "http://evil.example", http.StatusForbidden},
+		{"null Origin", "127.0.0.1:52000", "127.0.0.1:7777", "null", http.StatusForbidden},
+	}
+	s, _ := newTestServer(t)
+	h := s.Handler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := localRequest("GET", "/api/timer", "")
+			r.RemoteAddr, r.Host = tt.remote, tt.host
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandsRunInPoll(t *testing.T) {
+	tests := []struct {
+		path, body, want string
+	}{
+		{"/api/timer/start", "", "start"},
+		{"/api/timer/pause", "", "pause"},
+		{"/api/timer/stop", "", "stop"},
+		{"/api/mode", `{"mode": "pacman"}`, "mode pacman"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			s, b := newTestServer(t)
+			h := s.Handler()
+			w := httptest.NewRecorder()
+			done := make(chan struct{})
+			go func() {
+				h.ServeHTTP(w, localRequest("POST", tt.path, tt.body))
+				close(done)
+			}()
+
+			// The handler queues the command and waits
+			for deadline := time.Now().Add(2 * time.Second); len(s.cmds) == 0; {
+				if time.Now().After(deadline) {
+					t.Fatal("the command was never queued")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			select {
+			case <-done:
+				t.Fatalf("answered %d before Poll", w.Code)
+			default:
+			}
+			if len(b.calls) != 0 {
+				t.Fatalf("the backend ran %v outside Poll", b.calls)
+			}
+
+			s.Poll()
+			<-done
+			if w.Code != http.StatusOK {
+				t.Errorf("got %d: %s", w.Code, w.Body)
+			}
+			if !slices.Equal(b.calls, []string{tt.want}) {
+				t.Errorf("got %v, want [%s]", b.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	s, b := newTestServer(t)
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/api/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("got Content-Type %q, want text/event-stream", ct)
+	}
+
+	rd := bufio.NewReader(resp.Body)
+	next := func() TimerState {
+		t.Helper()
+		var data string
+		for {
+			line, err := rd.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading the stream: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			if line == "" {
+				break
+			}
+			if d, ok := strings.CutPrefix(line, "data: "); ok {
+				data = d
+			}
+		}
+		var snap Snapshot
+		if err := json.Unmarshal([]byte(data), &snap); err != nil {
+			t.Fatalf("frame without a snapshot: %q", data)
+		}
+		return snap.Timer
+	}
+
+	// The current state comes first, then one frame per second ticked
+	if got := next(); got.RemainingSec != 1500 {
+		t.Fatalf("first frame: got %v remaining, want 1500", got.RemainingSec)
+	}
+	b.snap.Timer.Status = "running"
+	for i := 1; i <= 3; i++ {
+		b.snap.Timer.RemainingSec = float64(1500 - i)
+		s.Poll()
+		if got := next(); got.RemainingSec != b.snap.Timer.RemainingSec || got.Status != "running" {
+			t.Errorf("tick %d: got %v %s, want %v running", i, got.RemainingSec, got.Status, b.snap.Timer.RemainingSec)
+		}
+	}
+}
+
+func TestToday(t *testing.T) {
+	log := history.Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	day := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	for _, e := range []history.Entry{
+		{Start: day.Add(9 * time.Hour), PlannedSec: 1500, ActualSec: 1500, Outcome: history.OutcomeCompleted},
+		{Start: day.Add(10 * time.Hour), PlannedSec: 1500, ActualSec: 300, Outcome: history.OutcomeAbandoned},
+		{Start: day.Add(-time.Hour), PlannedSec: 1500, ActualSec: 1500, Outcome: history.OutcomeCompleted},
+	} {
+		if err := log.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b := &fakeBackend{snap: Snapshot{Today: Today{Date: "2025-01-06", FishCaught: 3}}}
+	s := NewServer(b, log, clock.NewManual(day.Add(18*time.Hour)))
+	s.Poll()
+
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, localRequest("GET", "/api/today", ""))
+	var got Today
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	want := Today{Date: "2025-01-06", FishCaught: 3, FocusSec: 1800, CompletedSessions: 1}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -3,27 +3,290 @@ package assets
 import (
     "bytes"
     "embed"
+    "encoding/json"
+    "fmt"
     "image"
+    "image/color"
     _ "image/png" // Register PNG format
-    "log"
+    "io"
+    "io/fs"
+    "os"
+    "path"
+    "path/filepath"
+    "slices"
+    "sync"
+    "time"
 
     "github.com/hajimehoshi/ebiten/v2"
+    "github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+    "github.com/hajimehoshi/ebiten/v2/audio/wav"
+    "github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
-//go:embed images/*.png
+//go:embed images
 var projectAssets embed.FS
 
-// LoadImage loads a PNG sprite sheet into VRAM
-func LoadImage(name string) *ebiten.Image {
-    fileData, err := projectAssets.ReadFile("images/" + name)
+// Default serves the assets compiled into the binary.
+var Default = New(projectAssets)
+
+// Registry loads assets by path (e.g. "images/panda.png") from a file
+// system and caches the decoded result, including failures, until
+// Invalidate or Poll notices the file changed. It is safe for concurrent
+// use.
+type Registry struct {
+    mu    sync.Mutex
+    fsys  fs.FS
+    cache map[key]entry
+
+    modTimes  map[string]time.Time // Of every file loaded; zero if missing
+    listeners []*listener
+}
+
+// listener is a pointer so Subscribe's cancel can find its own.
+type listener struct{ fn func(name string) }
+
+// key separates decodings of the same file (e.g. raw bytes and image).
+type key struct{ kind, name string }
+
+type entry struct {
+    v   any
+    err error
+}
+
+func New(fsys fs.FS) *Registry {
+    return &Registry{fsys: fsys, cache: map[key]entry{}, modTimes: map[string]time.Time{}}
+}
+
+// SetFS swaps the file system (e.g. for the on-disk asset directory) and
+// drops everything cached from the old one.
+func (r *Registry) SetFS(fsys fs.FS) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.fsys = fsys
+    r.cache = map[key]entry{}
+    r.modTimes = map[string]time.Time{}
+}
+
+// Invalidate forgets the cached asset at name so the next call reloads it.
+func (r *Registry) Invalidate(name string) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for k := range r.cache {
+        if k.name == name {
+            delete(r.cache, k)
+        }
+    }
+}
+
+// load returns the cached kind of value for name, decoding it on first
+// use.
+func (r *Registry) load(kind, name string, decode func([]byte) (any, error)) (any, error) {
+    k := key{kind, name}
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if e, ok := r.cache[k]; ok {
+        return e.v, e.err
+    }
+    var e entry
+    r.modTimes[name] = r.modTime(name)
+    data, err := fs.ReadFile(r.fsys, name)
+    if err == nil {
+        e.v, err = decode(data)
+    }
     if err != nil {
-        log.Fatalf("Failed to read image '%s': %v", name, err)
+        e.err = fmt.Errorf("assets: %s: %w", name, err)
     }
+    r.cache[k] = e
+    return e.v, e.err
+}
 
-    img, _, err := image.Decode(bytes.NewReader(fileData))
+// --- Hot Reload ---
+
+// Subscribe registers fn to hear about every file Poll finds changed.
+// The cache has already been cleared for it, so fn can simply load it
+// again. Call cancel when whatever fn belongs to goes away; until then
+// the registry keeps it alive.
+func (r *Registry) Subscribe(fn func(name string)) (cancel func()) {
+    l := &listener{fn}
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.listeners = append(r.listeners, l)
+    return func() {
+        r.mu.Lock()
+        defer r.mu.Unlock()
+        r.listeners = slices.DeleteFunc(r.listeners, func(x *listener) bool { return x == l })
+    }
+}
+
+// Poll checks the modification time of every file loaded so far, drops
+// the cached copies of those that changed (or appeared, or vanished) and
+// notifies subscribers. Embedded files never change; point the registry
+// at the disk with SetFS to develop against live files. Listeners run on
+// the caller's goroutine.
+func (r *Registry) Poll() []string {
+    r.mu.Lock()
+    var changed []string
+    for name, old := range r.modTimes {
+        if mod := r.modTime(name); !mod.Equal(old) {
+            changed = append(changed, name)
+            r.modTimes[name] = mod
+            for k := range r.cache {
+                if k.name == name {
+                    delete(r.cache, k)
+                }
+            }
+        }
+    }
+    listeners := slices.Clone(r.listeners) // A listener may cancel itself
+    r.mu.Unlock()
+
+    for _, name := range changed {
+        for _, l := range listeners {
+            l.fn(name)
+        }
+    }
+    return changed
+}
+
+func (r *Registry) modTime(name string) time.Time {
+    fi, err := fs.Stat(r.fsys, name)
+    if err != nil {
+        return time.Time{}
+    }
+    return fi.ModTime()
+}
+
+// --- Raw Data ---
+
+// Data returns a copy of the raw bytes of a file, the caller's to
+// change.
+func (r *Registry) Data(name string) ([]byte, error) {
+    v, err := r.load("data", name, func(d []byte) (any, error) { return d, nil })
     if err != nil {
-        log.Fatalf("Failed to decode image '%s': %v", name, err)
+        return nil, err
+    }
+    return slices.Clone(v.([]byte)), nil
+}
+
+// JSON decodes a JSON file into v. It is not cached: every call gets a
+// fresh copy to modify.
+func (r *Registry) JSON(name string, v any) error {
+    data, err := r.Data(name)
+    if err != nil {
+        return err
+    }
+    if err := json.Unmarshal(data, v); err != nil {
+        return fmt.Errorf("assets: %s: %w", name, err)
+    }
+    return nil
+}
+
+// --- Images ---
+
+// Image returns a decoded image. If it is missing or broken the error
+// says why, and the image returned is a checkerboard placeholder, so
+// callers can log and keep drawing.
+func (r *Registry) Image(name string) (*ebiten.Image, error) {
+    v, err := r.load("image", name, func(d []byte) (any, error) {
+        img, _, err := image.Decode(bytes.NewReader(d))
+        if err != nil {
+            return nil, err
+        }
+        return ebiten.NewImageFromImage(img), nil
+    })
+    if err != nil {
+        return Placeholder(), err
+    }
+    return v.(*ebiten.Image), nil
+}
+
+var (
+    placeholderOnce sync.Once
+    placeholder     *ebiten.Image
+)
+
+// Placeholder is a 32x32 magenta and black checkerboard, the classic
+// missing-texture pattern.
+func Placeholder() *ebiten.Image {
+    placeholderOnce.Do(func() {
+        const size, cell = 32, 4
+        img := image.NewRGBA(image.Rect(0, 0, size, size))
+        for y := 0; y < size; y++ {
+            for x := 0; x < size; x++ {
+                c := color.RGBA{255, 0, 255, 255}
+                if (x/cell+y/cell)%2 == 1 {
+                    c = color.RGBA{0, 0, 0, 255}
+                }
+                img.SetRGBA(x, y, c)
+            }
+        }
+        placeholder = ebiten.NewImageFromImage(img)
+    })
+    return placeholder
+}
+
+// --- Fonts ---
+
+// Font returns a TrueType/OpenType font source; make faces from it with
+// &text.GoTextFace{Source: src, Size: n}.
+func (r *Registry) Font(name string) (*text.GoTextFaceSource, error) {
+    v, err := r.load("font", name, func(d []byte) (any, error) {
+        return text.NewGoTextFaceSource(bytes.NewReader(d))
+    })
+    if err != nil {
+        return nil, err
+    }
+    return v.(*text.GoTextFaceSource), nil
+}
+
+// --- Audio ---
+
+// Audio decodes a .wav or .ogg file to 16-bit stereo PCM at sampleRate,
+// ready for audio.NewPlayerFromBytes.
+func (r *Registry) Audio(name string, sampleRate int) ([]byte, error) {
+    v, err := r.load(fmt.Sprintf("audio@%d", sampleRate), name, func(d []byte) (any, error) {
+        var s io.Reader
+        var err error
+        switch path.Ext(name) {
+        case ".wav":
+            s, err = wav.DecodeWithSampleRate(sampleRate, bytes.NewReader(d))
+        case ".ogg":
+            s, err = vorbis.DecodeWithSampleRate(sampleRate, bytes.NewReader(d))
+        default:
+            return nil, fmt.Errorf("unsupported audio format %q", path.Ext(name))
+        }
+        if err != nil {
+            return nil, err
+        }
+        return io.ReadAll(s)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return v.([]byte), nil
+}
+
+// --- Default Registry ---
+
+// UseDir serves Default from the asset tree on disk (the directory
+// holding images/, i.e. internal/assets in a checkout) instead of the
+// copy compiled in, so edits show up on the next Poll.
+func UseDir(dir string) error {
+    fi, err := os.Stat(filepath.Join(dir, "images"))
+    if err != nil {
+        return fmt.Errorf("assets: %s is not an asset directory: %w", dir, err)
+    }
+    if !fi.IsDir() {
+        return fmt.Errorf("assets: %s/images is not a directory", dir)
     }
+    Default.SetFS(os.DirFS(dir))
+    return nil
+}
 
-    return ebiten.NewImageFromImage(img)
-}
\ No newline at end of file
+func Data(name string) ([]byte, error)                 { return Default.Data(name) }
+func JSON(name string, v any) error                    { return Default.JSON(name, v) }
+func Image(name string) (*ebiten.Image, error)         { return Default.Image(name) }
+func Font(name string) (*text.GoTextFaceSource, error) { return Default.Font(name) }
+func Audio(name string, sampleRate int) ([]byte, error) {
+    return Default.Audio(name, sampleRate)
+}
@@ -0,0 +1,46 @@
+package assets
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestDataIsACopy(t *testing.T) {
+	r := New(fstest.MapFS{"a.txt": {Data: []byte("panda")}})
+	d, err := r.Data("a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d[0] = 'P'
+	if again, _ := r.Data("a.txt"); string(again) != "panda" {
+		t.Errorf("changing a returned slice changed the cache: %q", again)
+	}
+}
+
+func TestPollAndCancel(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("one"), ModTime: time.Unix(1, 0)}}
+	r := New(fsys)
+	r.Data("a.txt")
+
+	var a, b []string
+	cancelA := r.Subscribe(func(name string) { a = append(a, name) })
+	r.Subscribe(func(name string) { b = append(b, name) })
+
+	if changed := r.Poll(); changed != nil {
+		t.Fatalf("nothing edited, Poll reported %v", changed)
+	}
+	fsys["a.txt"] = &fstest.MapFile{Data: []byte("two"), ModTime: time.Unix(2, 0)}
+	r.Poll()
+	if d, _ := r.Data("a.txt"); string(d) != "two" {
+		t.Errorf("after the edit, got %q", d)
+	}
+
+	cancelA()
+	fsys["a.txt"] = &fstest.MapFile{Data: []byte("three"), ModTime: time.Unix(3, 0)}
+	r.Poll()
+	if !slices.Equal(a, []string{"a.txt"}) || !slices.Equal(b, []string{"a.txt", "a.txt"}) {
+		t.Errorf("notified %v and %v, want the cancelled listener to hear only the first edit", a, b)
+	}
+}
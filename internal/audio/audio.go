@@ -0,0 +1,212 @@
+// Package audio plays the app's sound: one-shot effects (timer chimes,
+// fishing bites, Panda-Man dots), looping ambience for focus sessions
+// and the Music mode's songs, through ebiten's audio package.
+//
+// Every sound has a synthesized fallback, so nothing needs shipping; a
+// file "sounds/<name>.ogg" or ".wav" in the asset registry replaces it.
+package audio
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	ebaudio "github.com/hajimehoshi/ebiten/v2/audio"
+
+	"panda/internal/assets"
+)
+
+// SampleRate of every sound, in Hz.
+const SampleRate = 44100
+
+// Sound names.
+const (
+	SoundNear = "near" // Focus session nearly over
+	SoundDone = "done" // Focus session complete
+	SoundBell = "bell" // Break over
+	SoundBite = "bite" // A fish took the bait
+	SoundDot  = "dot"  // Panda-Man ate a dot
+)
+
+// Ambient loops, by name. Off is silence.
+const (
+	AmbientOff  = ""
+	AmbientRain = "rain"
+	AmbientCafe = "cafe"
+	AmbientLoFi = "lofi"
+)
+
+// Ambients lists the loops in menu order.
+var Ambients = []string{AmbientOff, AmbientRain, AmbientCafe, AmbientLoFi}
+
+// Volumes are the saved mixer levels, each 0-100. Music covers the
+// ambient loops and songs; SFX everything else.
+type Volumes struct {
+	Master int `json:"master"`
+	Music  int `json:"music"`
+	SFX    int `json:"sfx"`
+
+	// Ambient plays during focus sessions; AmbientOff for none.
+	Ambient string `json:"ambient,omitempty"`
+}
+
+func DefaultVolumes() Volumes {
+	return Volumes{Master: 80, Music: 60, SFX: 80}
+}
+
+func (v Volumes) music() float64 { return float64(v.Master) / 100 * float64(v.Music) / 100 }
+func (v Volumes) sfx() float64   { return float64(v.Master) / 100 * float64(v.SFX) / 100 }
+
+// Engine mixes the sounds. A nil *Engine is valid and silent, for
+// headless runs.
+type Engine struct {
+	ctx *ebaudio.Context
+	vol Volumes
+
+	mu  sync.Mutex
+	pcm map[string][]byte // Decoded or synthesized sounds by name
+
+	ambient     *ebaudio.Player
+	ambientName string
+	music       *ebaudio.Player
+}
+
+// New opens the audio device. ebiten allows only one audio context per
+// process, so there should only be one Engine.
+func New(vol Volumes) *Engine {
+	ctx := ebaudio.CurrentContext()
+	if ctx == nil {
+		ctx = ebaudio.NewContext(SampleRate)
+	}
+	e := &Engine{ctx: ctx, vol: vol, pcm: map[string][]byte{}}
+	assets.Default.Subscribe(e.assetChanged)
+	return e
+}
+
+// assetChanged drops a replaced sound file so the next Play picks it up.
+func (e *Engine) assetChanged(name string) {
+	if !strings.HasPrefix(name, "sounds/") {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	clear(e.pcm)
+}
+
+// SetVolumes applies new levels, including to the sounds already
+// playing.
+func (e *Engine) SetVolumes(v Volumes) {
+	if e == nil {
+		return
+	}
+	e.vol = v
+	for _, p := range []*ebaudio.Player{e.ambient, e.music} {
+		if p != nil {
+			p.SetVolume(v.music())
+		}
+	}
+}
+
+// Play starts a one-shot sound effect.
+func (e *Engine) Play(name string) {
+	if e == nil || e.vol.sfx() == 0 {
+		return
+	}
+	p := e.ctx.NewPlayerFromBytes(e.sound(name))
+	p.SetVolume(e.vol.sfx())
+	p.Play()
+}
+
+// SetAmbient switches the ambient loop; AmbientOff (or an unknown name)
+// stops it. Asking for the loop already playing does nothing.
+func (e *Engine) SetAmbient(name string) {
+	if e == nil || name == e.ambientName {
+		return
+	}
+	if e.ambient != nil {
+		e.ambient.Close()
+		e.ambient = nil
+	}
+	e.ambientName = name
+	if _, ok := ambients[name]; !ok {
+		return
+	}
+	e.ambient = e.loop(e.sound(name))
+}
+
+func (e *Engine) loop(pcm []byte) *ebaudio.Player {
+	return e.stream(ebaudio.NewInfiniteLoop(bytes.NewReader(pcm), int64(len(pcm))))
+}
+
+// stream starts playing src at the Music level.
+func (e *Engine) stream(src io.Reader) *ebaudio.Player {
+	p, err := e.ctx.NewPlayer(src)
+	if err != nil {
+		log.Printf("audio: %v", err)
+		return nil
+	}
+	p.SetVolume(e.vol.music())
+	p.Play()
+	return p
+}
+
+// --- Music ---
+
+// PlayMusic replaces the current song with src: 16-bit little-endian
+// stereo PCM at SampleRate, played until it ends. It reports whether the
+// song started; without a device or a player for it, nothing plays.
+func (e *Engine) PlayMusic(src io.Reader) bool {
+	if e == nil {
+		return false
+	}
+	e.StopMusic()
+	e.music = e.stream(src)
+	return e.music != nil
+}
+
+func (e *Engine) StopMusic() {
+	if e == nil || e.music == nil {
+		return
+	}
+	e.music.Close()
+	e.music = nil
+}
+
+// MusicPlaying reports whether a song is playing; it turns false by
+// itself when the song ends.
+func (e *Engine) MusicPlaying() bool {
+	return e != nil && e.music != nil && e.music.IsPlaying()
+}
+
+// MusicPosition is how far into the current song playback is.
+func (e *Engine) MusicPosition() time.Duration {
+	if e == nil || e.music == nil {
+		return 0
+	}
+	return e.music.Position()
+}
+
+// sound returns the PCM for name: the asset file if there is one,
+// otherwise the synthesized version.
+func (e *Engine) sound(name string) []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if pcm, ok := e.pcm[name]; ok {
+		return pcm
+	}
+	var pcm []byte
+	for _, ext := range []string{".ogg", ".wav"} {
+		if b, err := assets.Audio("sounds/"+name+ext, SampleRate); err == nil {
+			pcm = b
+			break
+		}
+	}
+	if pcm == nil {
+		pcm = synthesize(name)
+	}
+	e.pcm[name] = pcm
+	return pcm
+}
@@ -0,0 +1,222 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+)
+
+// The built-in sounds are rendered once, the first time they are played,
+// as mono float samples and then converted to the 16-bit stereo PCM
+// ebiten plays.
+
+// synthesize renders the built-in version of a sound; unknown names are
+// silent.
+func synthesize(name string) []byte {
+	if gen, ok := ambients[name]; ok {
+		return pcm16(gen())
+	}
+	var s []float64
+	switch name {
+	case SoundNear:
+		s = bell(noteFreq(81), 1.2, 0.5) // A5
+	case SoundDone:
+		s = samples(1.8)
+		for i, n := range []int{72, 76, 79, 84} { // C major, rising
+			mix(s, bell(noteFreq(n), 1.2, 0.3), seconds(0.14*float64(i)))
+		}
+	case SoundBell:
+		s = samples(1.4)
+		mix(s, bell(noteFreq(79), 1, 0.4), 0)            // G5
+		mix(s, bell(noteFreq(72), 1, 0.4), seconds(0.2)) // C5
+	case SoundBite:
+		s = sweep(700, 180, 0.15, 0.6)
+	case SoundDot:
+		s = blip(noteFreq(79), 0.05, 0.25)
+	}
+	return pcm16(s)
+}
+
+// --- Instruments ---
+
+// bell is a struck tone: a few inharmonic partials that die away at
+// different speeds, faded out over the last tenth so it never clicks.
+func bell(freq, dur, amp float64) []float64 {
+	s := samples(dur)
+	partials := []struct{ ratio, amp, decay float64 }{
+		{1, 1, 3}, {2.76, 0.4, 5}, {5.4, 0.2, 8},
+	}
+	for i := range s {
+		t := float64(i) / SampleRate
+		v := 0.0
+		for _, p := range partials {
+			v += p.amp * math.Sin(2*math.Pi*freq*p.ratio*t) * math.Exp(-p.decay*t)
+		}
+		s[i] = amp * v * attack(t, 0.005) * math.Min(1, (dur-t)/(dur/10))
+	}
+	return s
+}
+
+// sweep glides a sine from one pitch to another, fading out: a plop.
+func sweep(from, to, dur, amp float64) []float64 {
+	s := samples(dur)
+	phase := 0.0
+	for i := range s {
+		f := float64(i) / float64(len(s))
+		phase += 2 * math.Pi * (from + (to-from)*f) / SampleRate
+		s[i] = amp * math.Sin(phase) * (1 - f) * attack(float64(i)/SampleRate, 0.003)
+	}
+	return s
+}
+
+// blip is a short square-wave beep.
+func blip(freq, dur, amp float64) []float64 {
+	s := samples(dur)
+	for i := range s {
+		t := float64(i) / SampleRate
+		s[i] = amp * square(freq*t) * (1 - t/dur)
+	}
+	return s
+}
+
+// --- Ambient Loops ---
+
+// ambients render the loops; each wraps around seamlessly.
+var ambients = map[string]func() []float64{
+	AmbientRain: rain,
+	AmbientCafe: cafe,
+	AmbientLoFi: lofi,
+}
+
+// rain is soft filtered noise with droplets pattering on top.
+func rain() []float64 {
+	rng := rand.New(rand.NewSource(1))
+	s := samples(6)
+	lp := 0.0
+	for i := range s {
+		lp += 0.15 * (rng.Float64()*2 - 1 - lp)
+		s[i] = 0.35 * lp
+	}
+	for range 180 {
+		f := 2000 + rng.Float64()*3000
+		mix(s, sweep(f, f/2, 0.03, 0.02+rng.Float64()*0.04), rng.Intn(len(s)))
+	}
+	return s
+}
+
+// cafe is a low murmur of voices, the odd cup clinking.
+func cafe() []float64 {
+	rng := rand.New(rand.NewSource(2))
+	s := samples(8)
+	lp, bp := 0.0, 0.0
+	for i := range s {
+		t := float64(i) / SampleRate
+		lp += 0.02 * (rng.Float64()*2 - 1 - lp)
+		bp += 0.3 * (lp - bp)
+		// Chatter swells and fades; whole cycles per loop keep it seamless
+		swell := 0.6 + 0.4*math.Sin(2*math.Pi*t/4)*math.Sin(2*math.Pi*t/8*3)
+		s[i] = 1.6 * bp * swell
+	}
+	for range 5 {
+		mix(s, bell(2200+rng.Float64()*800, 0.5, 0.05), rng.Intn(len(s)))
+	}
+	return s
+}
+
+// lofi is a slow four-chord loop (Cmaj7 Am7 Dm7 G7) on a soft beat, with
+// vinyl crackle.
+func lofi() []float64 {
+	const bar = 2.0 // Seconds; 120 BPM
+	rng := rand.New(rand.NewSource(3))
+	chords := [][]int{{60, 64, 67, 71}, {57, 60, 64, 67}, {62, 65, 69, 72}, {55, 59, 62, 65}}
+	s := samples(bar * float64(len(chords)))
+	for c, notes := range chords {
+		at := seconds(bar * float64(c))
+		for _, n := range notes {
+			mix(s, pad(noteFreq(n), bar, 0.06), at)
+		}
+		mix(s, pad(noteFreq(notes[0]-24), bar, 0.12), at) // Bass
+		for beat := range 4 {
+			b := at + seconds(bar/4*float64(beat))
+			if beat%2 == 0 {
+				mix(s, sweep(120, 45, 0.18, 0.5), b) // Kick
+			}
+			mix(s, hat(rng, 0.03), b+seconds(bar/8)) // Off-beat hat
+		}
+	}
+	for range 60 {
+		s[rng.Intn(len(s))] += (rng.Float64()*2 - 1) * 0.15 // Crackle
+	}
+	return s
+}
+
+// pad is a mellow triangle tone with slow attack and release.
+func pad(freq, dur, amp float64) []float64 {
+	s := samples(dur)
+	for i := range s {
+		t := float64(i) / SampleRate
+		env := math.Min(attack(t, 0.15), math.Min(1, (dur-t)/0.3))
+		s[i] = amp * triangle(freq*t) * env
+	}
+	return s
+}
+
+// hat is a burst of bright noise.
+func hat(rng *rand.Rand, amp float64) []float64 {
+	s := samples(0.04)
+	prev := 0.0
+	for i := range s {
+		n := rng.Float64()*2 - 1
+		s[i] = amp * (n - prev) * (1 - float64(i)/float64(len(s)))
+		prev = n
+	}
+	return s
+}
+
+// --- Helpers ---
+
+func samples(dur float64) []float64 { return make([]float64, seconds(dur)) }
+
+func seconds(d float64) int { return int(d * SampleRate) }
+
+// noteFreq is the pitch of MIDI note n (69 = A4 = 440Hz).
+func noteFreq(n int) float64 {
+	return 440 * math.Pow(2, float64(n-69)/12)
+}
+
+// attack ramps in over the first ramp seconds to avoid a click.
+func attack(t, ramp float64) float64 {
+	return math.Min(1, t/ramp)
+}
+
+// square and triangle are unit waves over phase (in cycles).
+func square(phase float64) float64 {
+	if math.Mod(phase, 1) < 0.5 {
+		return 1
+	}
+	return -1
+}
+
+func triangle(phase float64) float64 {
+	return 4*math.Abs(math.Mod(phase, 1)-0.5) - 1
+}
+
+// mix adds src into dst from offset at, wrapping past the end so loops
+// stay seamless.
+func mix(dst, src []float64, at int) {
+	for i, v := range src {
+		dst[(at+i)%len(dst)] += v
+	}
+}
+
+// pcm16 converts mono samples to 16-bit little-endian stereo.
+func pcm16(s []float64) []byte {
+	out := make([]byte, len(s)*4)
+	for i, v := range s {
+		v = math.Max(-1, math.Min(1, v))
+		x := uint16(int16(v * math.MaxInt16))
+		binary.LittleEndian.PutUint16(out[i*4:], x)
+		binary.LittleEndian.PutUint16(out[i*4+2:], x)
+	}
+	return out
+}
@@ -0,0 +1,272 @@
+// Package chiptune is a tiny four-channel tracker: two square waves, a
+// triangle and a noise channel, sequenced from text song files and
+// rendered to PCM a buffer at a time.
+//
+// A song file is a few directives followed by patterns. ';' starts a
+// comment:
+//
+//	title  Bamboo Breeze
+//	tempo  120      ; beats per minute
+//	speed  4        ; rows per beat
+//	order  A A B A  ; patterns in play order
+//
+//	pattern A
+//	C-5 E-4 C-3 C-2 ; square 1, square 2, triangle, noise
+//	... ... ... ...
+//	=== G-4 ... C-6
+//
+// Each row has one cell per channel: a note (C-4, F#3), "..." to let the
+// previous note ring on, or "===" to release it. Noise notes set the
+// noise clock (low notes rumble, high ones hiss) and always die away by
+// themselves.
+package chiptune
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Channels, in column order.
+const (
+	Square1  = iota // 50% duty lead
+	Square2         // 25% duty harmony
+	Triangle        // Bass
+	Noise           // Drums
+	NumChannels
+)
+
+// Note is a MIDI note number (60 = C-4), or one of the markers below.
+type Note int
+
+const (
+	Hold Note = -1 // "...": nothing new, let the last note ring
+	Off  Note = -2 // "===": release the last note
+)
+
+type Row [NumChannels]Note
+
+type Pattern struct {
+	Name string
+	Rows []Row
+}
+
+type Song struct {
+	Title    string
+	Tempo    int // Beats per minute
+	Speed    int // Rows per beat
+	Patterns []Pattern
+	Order    []int // Indexes into Patterns, in play order
+}
+
+// RowDuration is how long each row plays.
+func (s *Song) RowDuration() time.Duration {
+	return time.Minute / time.Duration(s.Tempo*s.Speed)
+}
+
+// Len is the number of rows in one play-through.
+func (s *Song) Len() int {
+	n := 0
+	for _, p := range s.Order {
+		n += len(s.Patterns[p].Rows)
+	}
+	return n
+}
+
+func (s *Song) Duration() time.Duration {
+	return time.Duration(s.Len()) * s.RowDuration()
+}
+
+// At finds where the song is after playing for d: the position in Order
+// and the row within that pattern. Past the end it wraps around.
+func (s *Song) At(d time.Duration) (order, row int) {
+	row = int(d/s.RowDuration()) % s.Len()
+	for i, p := range s.Order {
+		if n := len(s.Patterns[p].Rows); row >= n {
+			row -= n
+		} else {
+			return i, row
+		}
+	}
+	return 0, 0
+}
+
+// --- Parsing ---
+
+// Parse reads a song file; name is only used in error messages.
+func Parse(name string, r io.Reader) (*Song, error) {
+	s := &Song{Tempo: 120, Speed: 4}
+	var order []string
+	byName := map[string]int{}
+	var cur *Pattern // Pattern receiving rows, if any
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("chiptune: %s:%d: %s", name, line, fmt.Sprintf(format, args...))
+		}
+		text, _, _ := strings.Cut(sc.Text(), ";")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if cur != nil && isNote(fields[0]) {
+			row, err := parseRow(fields)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			cur.Rows = append(cur.Rows, row)
+			continue
+		}
+		cur = nil
+		switch key, args := fields[0], fields[1:]; key {
+		case "title":
+			s.Title = strings.Join(args, " ")
+		case "tempo", "speed":
+			if len(args) != 1 {
+				return nil, fail("%s takes one number", key)
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return nil, fail("bad %s %q", key, args[0])
+			}
+			if key == "tempo" {
+				s.Tempo = n
+			} else {
+				s.Speed = n
+			}
+		case "order":
+			order = append(order, args...)
+		case "pattern":
+			if len(args) != 1 {
+				return nil, fail("pattern takes one name")
+			}
+			if _, dup := byName[args[0]]; dup {
+				return nil, fail("pattern %s defined twice", args[0])
+			}
+			byName[args[0]] = len(s.Patterns)
+			s.Patterns = append(s.Patterns, Pattern{Name: args[0]})
+			cur = &s.Patterns[len(s.Patterns)-1]
+		default:
+			return nil, fail("unknown directive %q", key)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("chiptune: %s: %w", name, err)
+	}
+
+	if len(order) == 0 {
+		return nil, fmt.Errorf("chiptune: %s: no order", name)
+	}
+	for _, o := range order {
+		i, ok := byName[o]
+		if !ok {
+			return nil, fmt.Errorf("chiptune: %s: order names unknown pattern %s", name, o)
+		}
+		if len(s.Patterns[i].Rows) == 0 {
+			return nil, fmt.Errorf("chiptune: %s: pattern %s is empty", name, o)
+		}
+		s.Order = append(s.Order, i)
+	}
+	if s.Title == "" {
+		s.Title = strings.TrimSuffix(path.Base(name), path.Ext(name))
+	}
+	return s, nil
+}
+
+func parseRow(fields []string) (Row, error) {
+	var row Row
+	if len(fields) != NumChannels {
+		return row, fmt.Errorf("row has %d cells, want %d", len(fields), NumChannels)
+	}
+	for i, f := range fields {
+		n, err := ParseNote(f)
+		if err != nil {
+			return row, err
+		}
+		row[i] = n
+	}
+	return row, nil
+}
+
+func isNote(s string) bool {
+	_, err := ParseNote(s)
+	return err == nil
+}
+
+// ParseNote reads a tracker cell: "C-4", "F#3", "..." or "===".
+func ParseNote(s string) (Note, error) {
+	switch s {
+	case "...":
+		return Hold, nil
+	case "===":
+		return Off, nil
+	}
+	if len(s) != 3 {
+		return 0, fmt.Errorf("bad note %q", s)
+	}
+	semi := strings.IndexByte("C D EF G A B", s[0])
+	if semi < 0 || s[0] == ' ' {
+		return 0, fmt.Errorf("bad note %q", s)
+	}
+	switch s[1] {
+	case '-':
+	case '#':
+		semi++
+	default:
+		return 0, fmt.Errorf("bad note %q", s)
+	}
+	oct := int(s[2] - '0')
+	if oct < 0 || oct > 9 {
+		return 0, fmt.Errorf("bad note %q", s)
+	}
+	return Note(12*(oct+1) + semi), nil
+}
+
+// String formats n the way song files write it.
+func (n Note) String() string {
+	switch n {
+	case Hold:
+		return "..."
+	case Off:
+		return "==="
+	}
+	name := [12]string{"C-", "C#", "D-", "D#", "E-", "F-", "F#", "G-", "G#", "A-", "A#", "B-"}[n%12]
+	return name + strconv.Itoa(int(n)/12-1)
+}
+
+// --- Built-in Songs ---
+
+//go:embed songs
+var songFiles embed.FS
+
+// Songs parses the songs shipped with the app, in file name order. A
+// broken file is an error; the others still come back.
+func Songs() ([]*Song, error) {
+	var songs []*Song
+	var errs []error
+	entries, _ := fs.ReadDir(songFiles, "songs")
+	for _, e := range entries {
+		name := "songs/" + e.Name()
+		f, err := songFiles.Open(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s, err := Parse(name, f)
+		f.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		songs = append(songs, s)
+	}
+	return songs, errors.Join(errs...)
+}
@@ -0,0 +1,102 @@
+package chiptune
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSong = `title  Test Tune
+tempo  120      ; 8 rows a second
+speed  4
+order  A B A
+
+pattern A
+C-4 E-4 C-3 C-6
+... === ... ...
+
+pattern B
+F#3 ... ... ...
+`
+
+func TestParse(t *testing.T) {
+	s, err := Parse("songs/test.txt", strings.NewReader(testSong))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Title != "Test Tune" || s.Tempo != 120 || s.Speed != 4 || len(s.Patterns) != 2 {
+		t.Fatalf("got %+v", s)
+	}
+	if want := (Row{60, 64, 48, 84}); s.Patterns[0].Rows[0] != want {
+		t.Errorf("first row %v, want %v", s.Patterns[0].Rows[0], want)
+	}
+	if want := (Row{Hold, Off, Hold, Hold}); s.Patterns[0].Rows[1] != want {
+		t.Errorf("second row %v, want %v", s.Patterns[0].Rows[1], want)
+	}
+	if s.Len() != 5 || s.Duration() != 625*time.Millisecond {
+		t.Errorf("%d rows lasting %v, want 5 rows, 625ms", s.Len(), s.Duration())
+	}
+	for _, tt := range []struct{ rows, order, row int }{{0, 0, 0}, {2, 1, 0}, {4, 2, 1}, {5, 0, 0}} {
+		if order, row := s.At(s.RowDuration() * time.Duration(tt.rows)); order != tt.order || row != tt.row {
+			t.Errorf("At(%d rows) = %d, %d; want %d, %d", tt.rows, order, row, tt.order, tt.row)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, song, want string
+	}{
+		{"unknown directive", "volume 3\n", `:1: unknown directive "volume"`},
+		{"bad tempo", "tempo fast\n", `:1: bad tempo "fast"`},
+		{"tempo with two numbers", "tempo 120 140\n", ":1: tempo takes one number"},
+		{"pattern twice", "pattern A\nC-4 ... ... ...\npattern A\n", ":3: pattern A defined twice"},
+		{"short row", "pattern A\nC-4 ... ...\n", ":2: row has 3 cells, want 4"},
+		{"bad note", "pattern A\nC-4 H-4 ... ...\n", `:2: bad note "H-4"`},
+		{"no order", "pattern A\nC-4 ... ... ...\n", "no order"},
+		{"unknown pattern", "order A B\npattern A\nC-4 ... ... ...\n", "order names unknown pattern B"},
+		{"empty pattern", "order A\npattern A\n", "pattern A is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("x.txt", strings.NewReader(tt.song))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNote(t *testing.T) {
+	tests := []struct {
+		cell string
+		want Note
+		bad  bool
+	}{
+		{"C-4", 60, false},
+		{"F#3", 54, false},
+		{"B-0", 23, false},
+		{"...", Hold, false},
+		{"===", Off, false},
+		{"H-4", 0, true},
+		{"C4", 0, true},
+		{"C+4", 0, true},
+		{"C-x", 0, true},
+	}
+	for _, tt := range tests {
+		n, err := ParseNote(tt.cell)
+		if (err != nil) != tt.bad || n != tt.want {
+			t.Errorf("ParseNote(%q) = %v, %v", tt.cell, n, err)
+		}
+		if !tt.bad && n.String() != tt.cell {
+			t.Errorf("%q formats back as %q", tt.cell, n.String())
+		}
+	}
+}
+
+func TestSongs(t *testing.T) {
+	songs, err := Songs()
+	if err != nil || len(songs) == 0 {
+		t.Errorf("got %d songs, %v", len(songs), err)
+	}
+}
@@ -0,0 +1,189 @@
+package chiptune
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// Stream plays a song once as 16-bit little-endian stereo PCM, the format
+// ebiten's audio players read, ending with io.EOF. It synthesizes as it
+// goes, so a song costs no memory up front.
+type Stream struct {
+	song *Song
+	rate float64
+
+	order, row int // Next row to play
+	left       int // Samples until then
+	done       bool
+
+	voices  [NumChannels]voice
+	partial []byte // Rest of a frame the last Read had no room for
+}
+
+func NewStream(s *Song, sampleRate int) *Stream {
+	st := &Stream{song: s, rate: float64(sampleRate)}
+	for ch := range st.voices {
+		st.voices[ch] = newVoice(ch, st.rate)
+	}
+	return st
+}
+
+// Channel levels, chosen so that everything at full tilt stays below
+// clipping.
+var channelGain = [NumChannels]float64{0.18, 0.14, 0.32, 0.16}
+
+func (st *Stream) Read(p []byte) (int, error) {
+	n := copy(p, st.partial)
+	st.partial = st.partial[n:]
+	var frame [4]byte
+	for n < len(p) {
+		if !st.next() {
+			if n == 0 {
+				return 0, io.EOF
+			}
+			break
+		}
+		mixed := 0.0
+		for ch := range st.voices {
+			mixed += channelGain[ch] * st.voices[ch].sample()
+		}
+		x := uint16(int16(math.Max(-1, math.Min(1, mixed)) * math.MaxInt16))
+		binary.LittleEndian.PutUint16(frame[0:], x)
+		binary.LittleEndian.PutUint16(frame[2:], x)
+		c := copy(p[n:], frame[:])
+		st.partial = append(st.partial[:0], frame[c:]...)
+		n += c
+	}
+	return n, nil
+}
+
+// next advances one sample, triggering the next row when it is due. It
+// returns false once the song and the tails of its last notes are over.
+func (st *Stream) next() bool {
+	if st.left > 0 {
+		st.left--
+		return true
+	}
+	if st.done {
+		return false
+	}
+	pat := st.song.Patterns[st.song.Order[st.order]]
+	for ch, n := range pat.Rows[st.row] {
+		st.voices[ch].play(n)
+	}
+	st.left = int(st.song.RowDuration().Seconds()*st.rate) - 1
+	if st.row++; st.row == len(pat.Rows) {
+		st.row = 0
+		if st.order++; st.order == len(st.song.Order) {
+			// Let the last row ring out, then a short release
+			st.done = true
+			for ch := range st.voices {
+				st.voices[ch].play(Off)
+			}
+			st.left += int(0.3 * st.rate)
+		}
+	}
+	return true
+}
+
+// --- Voices ---
+
+// voice is one channel's oscillator and envelope.
+type voice struct {
+	ch   int
+	rate float64
+
+	step  float64 // Phase per sample, in cycles (noise: shift-register clocks)
+	phase float64
+	lfsr  uint16
+	noise float64 // Current noise output, +-1
+
+	gate      bool
+	attacking bool
+	env       float64
+	decay     float64 // Envelope rate towards the sustain level while gated
+	sustain   float64
+	release   float64
+}
+
+// perSample turns a time constant in seconds into a per-sample envelope rate.
+func perSample(tc, sampleRate float64) float64 {
+	return 1 - math.Exp(-1/(tc*sampleRate))
+}
+
+func newVoice(ch int, sampleRate float64) voice {
+	v := voice{ch: ch, rate: sampleRate, lfsr: 1, noise: 1, release: perSample(0.04, sampleRate)}
+	switch ch {
+	case Square1, Square2:
+		v.sustain, v.decay = 0.55, perSample(0.2, sampleRate)
+	case Triangle:
+		v.sustain, v.decay = 1, 1
+	}
+	return v
+}
+
+func (v *voice) play(n Note) {
+	switch n {
+	case Hold:
+		return
+	case Off:
+		v.gate = false
+		return
+	}
+	freq := 440 * math.Pow(2, float64(n-69)/12)
+	v.step = freq / v.rate
+	v.gate, v.attacking = true, true
+	if v.ch == Noise {
+		// Clocked well above the note so the hiss sits around it; low
+		// notes are long thumps, high ones short ticks
+		v.step *= 8
+		tc := math.Max(0.03, math.Min(0.3, 0.3*65.4/freq))
+		v.decay = perSample(tc, v.rate)
+	}
+}
+
+func (v *voice) sample() float64 {
+	switch {
+	case v.attacking:
+		// A couple of milliseconds up to full, so notes don't click
+		if v.env += 1 / (0.002 * v.rate); v.env >= 1 {
+			v.env, v.attacking = 1, false
+		}
+	case v.gate:
+		v.env += (v.sustain - v.env) * v.decay
+	default:
+		v.env -= v.env * v.release
+	}
+	if v.env < 1e-4 && !v.gate {
+		return 0
+	}
+
+	v.phase += v.step
+	var out float64
+	switch v.ch {
+	case Square1, Square2:
+		duty := 0.5
+		if v.ch == Square2 {
+			duty = 0.25
+		}
+		v.phase -= math.Floor(v.phase)
+		out = 1
+		if v.phase >= duty {
+			out = -1
+		}
+	case Triangle:
+		// 16 steps, like the old consoles
+		v.phase -= math.Floor(v.phase)
+		out = math.Round((4*math.Abs(v.phase-0.5)-1)*7.5) / 7.5
+	case Noise:
+		for v.phase >= 1 {
+			v.phase--
+			bit := (v.lfsr ^ v.lfsr>>1) & 1
+			v.lfsr = v.lfsr>>1 | bit<<14
+			v.noise = float64(v.lfsr&1)*2 - 1
+		}
+		out = v.noise
+	}
+	return out * v.env
+}
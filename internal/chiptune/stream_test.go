@@ -0,0 +1,42 @@
+package chiptune
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamLength(t *testing.T) {
+	s, err := Parse("test.txt", strings.NewReader(testSong))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const rate = 8000
+	// Every row of the order once, 1000 samples each at 8 rows a second,
+	// then 0.3s of release; four bytes a sample
+	want := (s.Len()*rate/8 + rate*3/10) * 4
+
+	for _, size := range []int{7, 4096} { // Odd sizes split frames across reads
+		st := NewStream(s, rate)
+		buf := make([]byte, size)
+		total := 0
+		for {
+			n, err := st.Read(buf)
+			total += n
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if total != want {
+			t.Errorf("reading %d at a time: %d bytes, want %d", size, total, want)
+		}
+		// It plays once; it doesn't loop
+		if n, err := st.Read(buf); n != 0 || !errors.Is(err, io.EOF) {
+			t.Errorf("read %d, %v after the end", n, err)
+		}
+	}
+}
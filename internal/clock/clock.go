@@ -0,0 +1,31 @@
+package clock
+
+import "time"
+
+// Clock is the time source for anything that measures real time (the
+// focus timer, play-time stats), so headless runs can control it.
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the wall clock.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Manual only moves when told to.
+type Manual struct {
+	t time.Time
+}
+
+func NewManual(start time.Time) *Manual {
+	return &Manual{t: start}
+}
+
+func (m *Manual) Now() time.Time { return m.t }
+
+func (m *Manual) Advance(d time.Duration) { m.t = m.t.Add(d) }
+
+func (m *Manual) Set(t time.Time) { m.t = t }
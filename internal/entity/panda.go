@@ -1,75 +1,128 @@
 package entity
 
 import (
-    "image"
+    "fmt"
+    "log"
+    "path"
+    "time"
+
     "github.com/hajimehoshi/ebiten/v2"
+
+    "panda/internal/anim"
     "panda/internal/assets"
 )
 
+// PandaState picks the animation clip; each state's String is the clip
+// name in panda.json.
+type PandaState int
+
+const (
+    PandaIdle PandaState = iota
+    PandaTyping
+    PandaFishing
+    PandaEating
+    PandaSleeping
+)
+
+func (s PandaState) String() string {
+    return [...]string{"idle", "typing", "fishing", "eating", "sleeping"}[s]
+}
+
 type Panda struct {
-    X, Y         float64
-    
+    X, Y  float64
+    Scale float64 // Retro Zoom
+    State PandaState
+
     // Sprite Sheet Data
-    spriteSheet  *ebiten.Image
-    frameWidth   int // Width of ONE frame
-    frameCount   int // Total frames in sheet
-    currentFrame int
-    
-    // Timing
-    tickCounter  int
-    speed        int // Ticks per frame (Lower = Faster)
+    spriteSheet *ebiten.Image
+    Anim        *anim.Player // Subscribe here for clip events ("chomp", "end", ...)
+
+    unsubscribe func() // From asset reloads
 }
 
-func NewPanda() *Panda {
-    // Load the Sprite Sheet
-    sheet := assets.LoadImage("panda_idle.png")
-    
-    // Auto-detect frame count based on aspect ratio
-    // Assumption: The sheet is a horizontal strip of square-ish frames
-    totalW, totalH := sheet.Bounds().Dx(), sheet.Bounds().Dy()
-    
-    // Simple logic: If height is 32, and width is 128, we have 4 frames.
-    // If it's a single image, width == height (usually).
-    count := totalW / totalH 
-    if count == 0 { count = 1 }
-
-    return &Panda{
-        X:           120,
-        Y:           100,
-        spriteSheet: sheet,
-        frameWidth:  totalH, // Assuming frames are square (32x32)
-        frameCount:  count,
-        speed:       15,     // Update every 15 ticks (approx 4 times/sec)
+const pandaManifest = "images/panda.json"
+
+// NewPanda loads the panda's clip manifest and the sheet it names. A
+// missing sheet only gets logged; the panda is drawn as a placeholder.
+// The panda reloads itself when the asset registry reports changes,
+// until Close.
+func NewPanda() (*Panda, error) {
+    p := &Panda{X: 120, Y: 100, Scale: 4}
+    if err := p.load(); err != nil {
+        return nil, err
     }
+    p.unsubscribe = assets.Default.Subscribe(p.assetChanged)
+    return p, nil
 }
 
-func (p *Panda) Update() {
-    p.tickCounter++
-
-    if p.tickCounter >= p.speed {
-        p.tickCounter = 0
-        p.currentFrame++
-        
-        // Loop Animation
-        if p.currentFrame >= p.frameCount {
-            p.currentFrame = 0
-        }
+// Close stops the panda following asset reloads, so a panda that is no
+// longer drawn can be collected.
+func (p *Panda) Close() {
+    p.unsubscribe()
+}
+
+func (p *Panda) load() error {
+    data, err := assets.Data(pandaManifest)
+    if err != nil {
+        return err
+    }
+    sheet, err := anim.Parse(data)
+    if err != nil {
+        return fmt.Errorf("%s: %w", pandaManifest, err)
+    }
+    img, err := assets.Image(p.sheetPath(sheet))
+    if err != nil {
+        log.Print(err)
     }
+
+    p.spriteSheet = img
+    if p.Anim == nil {
+        p.Anim = anim.NewPlayer(sheet)
+    } else {
+        p.Anim.SetSheet(sheet)
+    }
+    return nil
+}
+
+func (p *Panda) sheetPath(s *anim.Sheet) string {
+    return path.Join(path.Dir(pandaManifest), s.Image)
+}
+
+// assetChanged swaps in an edited manifest or sheet. A broken edit is
+// logged and the panda keeps what it had.
+func (p *Panda) assetChanged(name string) {
+    if name != pandaManifest && name != p.sheetPath(p.Anim.Sheet) {
+        return
+    }
+    if err := p.load(); err != nil {
+        log.Print(err)
+        return
+    }
+    log.Printf("reloaded %s", name)
+}
+
+// SetState switches to the state's clip. States without a clip in the
+// manifest fall back to idle.
+func (p *Panda) SetState(s PandaState) {
+    p.State = s
+    if !p.Anim.Play(s.String()) {
+        p.Anim.Play(PandaIdle.String())
+    }
+}
+
+func (p *Panda) Update() {
+    p.Anim.Update(time.Second / time.Duration(ebiten.TPS()))
 }
 
 func (p *Panda) Draw(screen *ebiten.Image) {
     if p.spriteSheet == nil { return }
 
-    // Math: Calculate where the current frame lives on the sheet
-    sx := p.currentFrame * p.frameWidth
-    
-    // Cut out the frame
-    rect := image.Rect(sx, 0, sx+p.frameWidth, p.spriteSheet.Bounds().Dy())
-    subImg := p.spriteSheet.SubImage(rect).(*ebiten.Image)
+    // Cut out the current frame
+    subImg := p.spriteSheet.SubImage(p.Anim.Frame()).(*ebiten.Image)
 
     op := &ebiten.DrawImageOptions{}
+    op.GeoM.Scale(p.Scale, p.Scale)
     op.GeoM.Translate(p.X, p.Y)
-    op.GeoM.Scale(4, 4) // Retro Zoom
 
     screen.DrawImage(subImg, op)
-}
\ No newline at end of file
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"panda/internal/assets"
+)
+
+// writeSheet puts a one-clip manifest and a w x 32 sheet in dir/images,
+// both stamped with mod.
+func writeSheet(t *testing.T, dir string, frameX, w int, mod time.Time) {
+	t.Helper()
+	manifest := fmt.Sprintf(`{
+		"frames": [{"frame": {"x": %d, "y": 0, "w": 32, "h": 32}, "duration": 100}],
+		"meta": {"image": "panda.png", "frameTags": [{"name": "idle", "from": 0, "to": 0}]}
+	}`, frameX)
+	writeAsset(t, dir, "panda.json", []byte(manifest), mod)
+
+	f, err := os.Create(filepath.Join(dir, "images", "panda.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, w, 32))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	os.Chtimes(f.Name(), mod, mod)
+}
+
+func writeAsset(t *testing.T, dir, name string, data []byte, mod time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, "images", name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(path, mod, mod)
+}
+
+func TestPandaHotReload(t *testing.T) {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, "images"), 0755)
+	mod := time.Now().Add(-time.Hour)
+	writeSheet(t, dir, 0, 32, mod)
+	if err := assets.UseDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPanda()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.spriteSheet.Bounds().Dx(); got != 32 || p.Anim.Frame() != image.Rect(0, 0, 32, 32) {
+		t.Fatalf("loaded a %dpx sheet showing %v", got, p.Anim.Frame())
+	}
+
+	// An artist widens the sheet and moves the frame
+	mod = mod.Add(time.Minute)
+	writeSheet(t, dir, 32, 64, mod)
+	assets.Default.Poll()
+	if got := p.spriteSheet.Bounds().Dx(); got != 64 || p.Anim.Frame() != image.Rect(32, 0, 64, 32) {
+		t.Errorf("after the edit: a %dpx sheet showing %v, want 64px showing the second frame", got, p.Anim.Frame())
+	}
+
+	// A half-saved manifest is ignored
+	mod = mod.Add(time.Minute)
+	writeAsset(t, dir, "panda.json", []byte(`{"frames": [`), mod)
+	assets.Default.Poll()
+	if p.Anim.Frame() != image.Rect(32, 0, 64, 32) {
+		t.Errorf("a broken manifest changed the frame to %v", p.Anim.Frame())
+	}
+
+	// A closed panda stops following edits
+	p.Close()
+	mod = mod.Add(time.Minute)
+	writeSheet(t, dir, 0, 32, mod)
+	assets.Default.Poll()
+	if p.Anim.Frame() != image.Rect(32, 0, 64, 32) {
+		t.Errorf("a closed panda reloaded, showing %v", p.Anim.Frame())
+	}
+}
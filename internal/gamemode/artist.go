@@ -0,0 +1,48 @@
+package gamemode
+
+import (
+	"image/color"
+	"log"
+
+	"panda/internal/entity"
+	"panda/internal/sprites"
+)
+
+// --- Colors ---
+var (
+	ColFishShadow = color.RGBA{0x00, 0x00, 0x00, 0x50}
+	ColWater      = color.RGBA{0x4e, 0xcd, 0xc4, 0xff}
+	ColMazeWall   = color.RGBA{0x55, 0x55, 0xff, 0xff}
+	ColDot        = color.RGBA{0xff, 0xb8, 0xae, 0xff}
+
+	colReelTrack = color.RGBA{50, 50, 50, 255}
+)
+
+// --- Costumed Sprites ---
+
+// Built once so each keeps its rendered image between frames.
+var (
+	pandaPlain   = sprites.Panda.With(sprites.PandaLimbs...)
+	pandaFishing = sprites.Panda.With(sprites.FishingRod, sprites.PandaFeet)
+	pandaTyping  = [2]*sprites.Sprite{ // Paws down, up
+		sprites.Panda.With(sprites.Desk, sprites.TypingPaws(false)),
+		sprites.Panda.With(sprites.Desk, sprites.TypingPaws(true)),
+	}
+)
+
+// --- Animated Panda ---
+
+// newPanda loads the animated panda from its sheet, centred on x, y at
+// twice its size. If the sheet won't load it logs why and returns nil;
+// the scene then falls back to its costumed sprite.
+func newPanda(x, y float64) *entity.Panda {
+	p, err := entity.NewPanda()
+	if err != nil {
+		log.Printf("panda: %v", err)
+		return nil
+	}
+	p.Scale = 2
+	f := p.Anim.Frame()
+	p.X, p.Y = x-float64(f.Dx())*p.Scale/2, y-float64(f.Dy())*p.Scale/2
+	return p
+}
@@ -0,0 +1,345 @@
+package gamemode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"panda/internal/audio"
+	"panda/internal/clock"
+	"panda/internal/history"
+	"panda/internal/input"
+	"panda/internal/pixeltext"
+	"panda/internal/pomodoro"
+	"panda/internal/scene"
+	"panda/internal/ui"
+)
+
+// Logical resolution every scene draws into (Retro 4:3).
+const (
+	ScreenWidth  = 320
+	ScreenHeight = 240
+)
+
+// Scene IDs registered with the scene manager.
+const (
+	ModeDirectory scene.ID = iota
+	ModeRelax
+	ModeFocus
+	ModeFishing
+	ModePacman
+	ModeSettings
+	ModeEating
+	ModeMusic
+	ModeStats
+	ModeEndless
+)
+
+// ModeNames are the scene IDs' external names (CLI, control API), indexed
+// by ID.
+var ModeNames = []string{"directory", "relax", "focus", "fishing", "pacman", "settings", "eating", "music", "stats", "endless"}
+
+// ModeName returns the external name of id.
+func ModeName(id scene.ID) string {
+	if int(id) >= 0 && int(id) < len(ModeNames) {
+		return ModeNames[id]
+	}
+	return fmt.Sprintf("mode(%d)", int(id))
+}
+
+// ModeByName looks a scene ID up by its external name.
+func ModeByName(name string) (scene.ID, bool) {
+	for i, n := range ModeNames {
+		if n == name {
+			return scene.ID(i), true
+		}
+	}
+	return 0, false
+}
+
+// --- Persisted Data ---
+
+type ColorProfile struct {
+	Name      string `json:"name"`
+	BgHex     string `json:"bg_hex"`
+	AccentHex string `json:"accent_hex"`
+	TextHex   string `json:"text_hex,omitempty"` // Picked to contrast with BgHex if empty
+}
+
+type AppSettings struct {
+	ActiveIndex int             `json:"active_profile_index"`
+	Profiles    []ColorProfile  `json:"profiles"`
+	Focus       pomodoro.Config `json:"focus"`
+	Bindings    input.Bindings  `json:"bindings,omitempty"` // Defaults fill any gaps
+	Audio       *audio.Volumes  `json:"audio,omitempty"`    // Nil until first saved
+}
+
+type GameStats struct {
+	TotalPlayTimeSec  int64  `json:"total_play_time"`
+	TodayPlayTimeSec  int64  `json:"today_play_time"`
+	LastLoginDate     string `json:"last_login_date"`
+	FishCaught        int    `json:"fish_caught"`
+	PacmanWinsToday   int    `json:"pacman_wins_today"`
+	PacmanHighScore   int    `json:"pacman_high_score"`
+	PacmanEndlessBest int    `json:"pacman_endless_best"` // Most boards cleared in one endless run
+	GhostsEaten       int    `json:"ghosts_eaten"`
+}
+
+// DefaultSettings is used when no settings file exists yet.
+func DefaultSettings() AppSettings {
+	return AppSettings{
+		ActiveIndex: 0,
+		Profiles: []ColorProfile{
+			{Name: "Retro", BgHex: "#2d2d2d", AccentHex: "#ff6b6b", TextHex: "#f0f0f0"},
+			{Name: "Light", BgHex: "#fdf6e3", AccentHex: "#2aa198", TextHex: "#073642"},
+			{Name: "Matrix", BgHex: "#000000", AccentHex: "#00ff00", TextHex: "#b8ffb8"},
+		},
+		Focus:    pomodoro.DefaultConfig(),
+		Bindings: input.DefaultBindings(),
+		Audio:    ptr(audio.DefaultVolumes()),
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// --- Shared Scene State ---
+
+// Context is the state every scene shares: persisted stats and settings,
+// the active theme colors, the manager used to switch scenes and the
+// input/time/random sources (swappable for headless runs).
+type Context struct {
+	Scenes *scene.Manager
+
+	Keys    *input.Keyboard
+	Pad     *input.Gamepad
+	Actions *input.Actions // Keys and Pad through the player's bindings
+	Pointer *input.Pointer
+	Clock   clock.Clock
+	Rand    *rand.Rand
+
+	// Timer is the Pomodoro cycle. It lives here rather than in FocusMode
+	// so it keeps running while other scenes are open.
+	Timer *pomodoro.Engine
+
+	// History is the focus session log; nil when nothing is recorded.
+	History *history.Log
+
+	// Audio plays sounds; nil (silent) in headless runs.
+	Audio      *audio.Engine
+	chimedNear bool // The session was already in its last 10% last tick
+
+	Stats    GameStats
+	Settings AppSettings
+
+	BgColor, AccentColor, TextColor color.RGBA
+
+	// Tick counts frames since launch; scenes use it for idle animation.
+	Tick int
+
+	// SaveSettings persists Settings; set by the owner of the save files.
+	SaveSettings func()
+}
+
+func NewContext(src input.Source, clk clock.Clock, rng *rand.Rand) *Context {
+	settings := DefaultSettings()
+	c := &Context{
+		Scenes:   scene.NewManager(),
+		Keys:     input.NewKeyboard(src),
+		Pad:      input.NewGamepad(src),
+		Pointer:  input.NewPointer(src),
+		Clock:    clk,
+		Rand:     rng,
+		Timer:    pomodoro.New(settings.Focus),
+		Settings: settings,
+	}
+	c.Actions = input.NewActions(c.Keys, c.Pad)
+	c.Timer.Subscribe(c.timerSound)
+	return c
+}
+
+// RegisterScenes adds every mode to the scene manager and opens the
+// directory. Stats and settings should be loaded first.
+func (c *Context) RegisterScenes() {
+	c.Scenes.Register(ModeDirectory, NewDirectoryMode(c))
+	c.Scenes.Register(ModeRelax, NewRelaxMode(c))
+	c.Scenes.Register(ModeFocus, NewFocusMode(c))
+	c.Scenes.Register(ModeFishing, NewFishingMode(c))
+	c.Scenes.Register(ModePacman, NewPacmanMode(c))
+	c.Scenes.Register(ModeSettings, NewSettingsMode(c))
+	c.Scenes.Register(ModeEating, NewPlaceholderMode(c, "EATING"))
+	c.Scenes.Register(ModeMusic, NewMusicMode(c))
+	c.Scenes.Register(ModeStats, NewStatsMode(c))
+	c.Scenes.Register(ModeEndless, NewEndlessPacmanMode(c))
+	c.Scenes.Switch(ModeDirectory)
+}
+
+// Update runs one tick: samples input, advances the focus timer, counts
+// play time, handles the global Back shortcut and updates the active
+// scene.
+func (c *Context) Update() error {
+	c.Tick++
+	c.Keys.Update()
+	c.Pad.Update()
+	c.Actions.Update()
+	c.Pointer.Update()
+	c.Timer.Update(c.Clock.Now())
+	c.updateAudio()
+	if c.Tick%60 == 0 {
+		c.Stats.TotalPlayTimeSec++
+		c.Stats.TodayPlayTimeSec++
+	}
+
+	// Back (or its on-screen button) always returns to the directory
+	back := c.Actions.JustPressed(input.ActionBack) ||
+		(c.Scenes.Current() != ModeDirectory && c.Pointer.JustPressed() && c.Pointer.In(backButton))
+	if back && !c.Actions.Capturing {
+		c.Scenes.Switch(ModeDirectory)
+	}
+	return c.Scenes.Update()
+}
+
+// --- Audio ---
+
+// updateAudio chimes when a work session enters its last 10% (when the
+// gopher shows up) and plays the ambient loop while one is running.
+func (c *Context) updateAudio() {
+	t := c.Timer
+	working := t.Phase == pomodoro.PhaseWork && t.Status == pomodoro.StatusRunning
+	near := t.Phase == pomodoro.PhaseWork && t.Status != pomodoro.StatusStopped && t.Fraction() <= 0.10
+	if near && !c.chimedNear {
+		c.Audio.Play(audio.SoundNear)
+	}
+	c.chimedNear = near
+
+	// Songs in the Music mode take over from the ambience
+	ambient := audio.AmbientOff
+	if working && !c.Audio.MusicPlaying() {
+		ambient = c.Settings.Audio.Ambient
+	}
+	c.Audio.SetAmbient(ambient)
+}
+
+// timerSound rings when a phase runs out.
+func (c *Context) timerSound(ev pomodoro.Event) {
+	if ev.Kind != pomodoro.EventCompleted {
+		return
+	}
+	if ev.Phase == pomodoro.PhaseWork {
+		c.Audio.Play(audio.SoundDone)
+	} else {
+		c.Audio.Play(audio.SoundBell)
+	}
+}
+
+// backButton is Back for mice and touchscreens, in the top-right corner of
+// every scene but the directory.
+var backButton = image.Rect(ScreenWidth-18, 2, ScreenWidth-2, 18)
+
+// Draw renders the active scene and the back button over it.
+func (c *Context) Draw(screen *ebiten.Image) {
+	c.Scenes.Draw(screen)
+	if c.Scenes.Current() == ModeDirectory {
+		return
+	}
+	r := backButton
+	vector.StrokeRect(screen, float32(r.Min.X)+0.5, float32(r.Min.Y)+0.5, float32(r.Dx())-1, float32(r.Dy())-1, 1, c.AccentColor, false)
+	pixeltext.Draw(screen, "x", float64(r.Min.X+r.Max.X)/2, float64(r.Min.Y+2), c.AccentStyle().WithAlign(pixeltext.Center))
+}
+
+// ActiveProfile returns the selected color profile, falling back to the
+// first one if the saved index is out of range.
+func (c *Context) ActiveProfile() ColorProfile {
+	idx := c.Settings.ActiveIndex
+	if idx < 0 || idx >= len(c.Settings.Profiles) {
+		idx = 0
+	}
+	return c.Settings.Profiles[idx]
+}
+
+// ApplySettings pushes freshly loaded Settings into the theme, timer,
+// input bindings and mixer.
+func (c *Context) ApplySettings() {
+	c.Settings.Focus = c.Settings.Focus.WithDefaults()
+	c.Timer.SetConfig(c.Settings.Focus)
+	c.Settings.Bindings = c.Settings.Bindings.WithDefaults()
+	c.Actions.SetBindings(c.Settings.Bindings)
+	if c.Settings.Audio == nil {
+		c.Settings.Audio = ptr(audio.DefaultVolumes())
+	}
+	c.Audio.SetVolumes(*c.Settings.Audio)
+	c.ApplyProfile()
+}
+
+// ApplyProfile refreshes the theme colors from the active profile.
+func (c *Context) ApplyProfile() {
+	if len(c.Settings.Profiles) == 0 {
+		c.Settings = DefaultSettings()
+	}
+	p := c.ActiveProfile()
+	c.BgColor = ParseHex(p.BgHex)
+	c.AccentColor = ParseHex(p.AccentHex)
+	if p.TextHex != "" {
+		c.TextColor = ParseHex(p.TextHex)
+	} else if isLight(c.BgColor) {
+		c.TextColor = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	} else {
+		c.TextColor = color.RGBA{0xf0, 0xf0, 0xf0, 0xff}
+	}
+}
+
+// TextStyle is the theme's body text: TextColor with a drop shadow that
+// stands out against the text.
+func (c *Context) TextStyle() pixeltext.Style {
+	shadow := color.RGBA{0, 0, 0, 0x80}
+	if !isLight(c.TextColor) {
+		shadow = color.RGBA{0xff, 0xff, 0xff, 0x60}
+	}
+	return pixeltext.Style{Color: c.TextColor, Shadow: shadow}
+}
+
+// AccentStyle is TextStyle in the accent color, for titles and
+// highlights.
+func (c *Context) AccentStyle() pixeltext.Style {
+	return c.TextStyle().WithColor(c.AccentColor)
+}
+
+// UITheme styles the ui toolkit widgets to match.
+func (c *Context) UITheme() ui.Theme {
+	return ui.Theme{Text: c.TextStyle(), Accent: c.AccentColor, Bg: c.BgColor}
+}
+
+// NewUI returns a widget toolkit reading the shared input.
+func (c *Context) NewUI() *ui.UI {
+	return ui.New(c.Actions, c.Pointer, image.Rect(0, 0, ScreenWidth, ScreenHeight))
+}
+
+// isLight reports whether c is closer to white than black (by perceived
+// luminance).
+func isLight(c color.RGBA) bool {
+	return 299*int(c.R)+587*int(c.G)+114*int(c.B) > 128*1000
+}
+
+func ParseHex(s string) color.RGBA {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{0, 0, 0, 255}
+	}
+	v, _ := strconv.ParseUint(s, 16, 32)
+	return color.RGBA{uint8(v >> 16), uint8((v >> 8) & 0xFF), uint8(v & 0xFF), 255}
+}
+
+// base gives scenes no-op Enter/Exit hooks and the fixed retro layout.
+type base struct{}
+
+func (base) Enter() {}
+func (base) Exit()  {}
+
+func (base) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return ScreenWidth, ScreenHeight
+}
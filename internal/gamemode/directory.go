@@ -0,0 +1,64 @@
+package gamemode
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/pixeltext"
+	"panda/internal/scene"
+	"panda/internal/ui"
+)
+
+// DirectoryMode is the main menu ("PANDA OS") linking to every other scene.
+type DirectoryMode struct {
+	base
+	ctx *Context
+	ui  *ui.UI
+}
+
+// directoryEntries are the menu buttons, in order, with their hotkeys.
+var directoryEntries = []struct {
+	label string
+	key   ebiten.Key
+	mode  scene.ID
+}{
+	{"[1] Chill", ebiten.Key1, ModeRelax},
+	{"[2] Focus Timer", ebiten.Key2, ModeFocus},
+	{"[3] Fishing Spots", ebiten.Key3, ModeFishing},
+	{"[4] Panda-Man", ebiten.Key4, ModePacman},
+	{"[5] Eating", ebiten.Key5, ModeEating},
+	{"[6] Music", ebiten.Key6, ModeMusic},
+	{"[7] Focus Stats", ebiten.Key7, ModeStats},
+	{"[8] Endless Panda-Man", ebiten.Key8, ModeEndless},
+	{"[S] Settings", ebiten.KeyS, ModeSettings},
+}
+
+func NewDirectoryMode(ctx *Context) *DirectoryMode {
+	return &DirectoryMode{ctx: ctx, ui: ctx.NewUI()}
+}
+
+func (d *DirectoryMode) Update() error {
+	u := d.ui
+	u.Begin(d.ctx.UITheme())
+	u.Column(4, 2, 150)
+	u.LabelStyle("--- PANDA OS ---", d.ctx.AccentStyle())
+	u.Spacer(14)
+	for i, e := range directoryEntries {
+		if i == len(directoryEntries)-1 {
+			u.Spacer(8)
+		}
+		if u.Button(e.label, e.key) {
+			d.ctx.Scenes.Switch(e.mode)
+		}
+	}
+	u.End()
+	return nil
+}
+
+func (d *DirectoryMode) Draw(screen *ebiten.Image) {
+	d.ui.Draw(screen)
+	pandaPlain.Draw(screen, 240, 150, nil)
+	msg := fmt.Sprintf("STATS:\nToday: %dm\nTotal: %dm", d.ctx.Stats.TodayPlayTimeSec/60, d.ctx.Stats.TotalPlayTimeSec/60)
+	pixeltext.Draw(screen, msg, 10, 192, d.ctx.TextStyle())
+}
@@ -0,0 +1,149 @@
+package gamemode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"panda/internal/audio"
+	"panda/internal/entity"
+	"panda/internal/input"
+	"panda/internal/pixeltext"
+)
+
+type FishingState int
+
+const (
+	FishingIdle    FishingState = iota // Waiting for a cast (Cast1-3 or tap a spot)
+	FishingWaiting                     // Bobber in the water
+	FishingReeling                     // Fish on the line, mash Reel or click
+)
+
+// The water along the bottom; each spot owns a third of it.
+var fishingWater = image.Rect(0, 180, ScreenWidth, ScreenHeight)
+
+// FishingMode: a fish shadow wanders between three spots; cast onto the
+// right one, wait for a bite and reel it in before the line goes slack.
+type FishingMode struct {
+	base
+	ctx   *Context
+	panda *entity.Panda // Nil if the sheet didn't load
+
+	State        FishingState
+	ActiveSpot   int
+	TargetSpot   int
+	BobberX      float64
+	BobberY      float64
+	ReelProgress float64
+	FishStrength float64
+	Score        int
+	WaitTimer    int
+}
+
+func NewFishingMode(ctx *Context) *FishingMode {
+	f := &FishingMode{ctx: ctx, panda: newPanda(160, 140)}
+	if f.panda != nil {
+		f.panda.SetState(entity.PandaFishing)
+	}
+	return f
+}
+
+func (f *FishingMode) Update() error {
+	f.WaitTimer++
+	if f.WaitTimer > 120 {
+		f.WaitTimer = 0
+		f.TargetSpot = f.ctx.Rand.Intn(3) + 1
+	}
+
+	switch f.State {
+	case FishingIdle:
+		target := 0
+		for i, a := range []input.Action{input.ActionCast1, input.ActionCast2, input.ActionCast3} {
+			if f.ctx.Actions.JustPressed(a) {
+				target = i + 1
+			}
+		}
+		if spot := f.tappedSpot(); spot > 0 {
+			target = spot
+		}
+		if target > 0 {
+			f.ActiveSpot = target
+			f.State = FishingWaiting
+			f.BobberX = float64(80 * target)
+			f.BobberY = 180
+		}
+
+	case FishingWaiting:
+		if f.ActiveSpot == f.TargetSpot && f.ctx.Rand.Intn(100) < 2 {
+			f.State = FishingReeling
+			f.ReelProgress = 30
+			f.ctx.Audio.Play(audio.SoundBite)
+			f.FishStrength = 0.5 + f.ctx.Rand.Float64()
+		}
+		if f.ctx.Actions.JustPressed(input.ActionReel) || f.ctx.Pointer.JustPressed() {
+			f.State = FishingIdle
+		}
+
+	case FishingReeling:
+		f.ReelProgress -= f.FishStrength
+		if f.ctx.Actions.JustPressed(input.ActionReel) || f.ctx.Pointer.JustPressed() {
+			f.ReelProgress += 8.0
+		}
+		if f.ReelProgress >= 100 {
+			f.Score++
+			f.ctx.Stats.FishCaught++
+			f.State = FishingIdle
+		}
+		if f.ReelProgress <= 0 {
+			f.State = FishingIdle
+		}
+	}
+	if f.panda != nil {
+		f.panda.Update()
+	}
+	return nil
+}
+
+// tappedSpot returns the spot (1-3) clicked or tapped this tick, or 0.
+func (f *FishingMode) tappedSpot() int {
+	p := f.ctx.Pointer
+	if !p.JustPressed() || !p.In(fishingWater) {
+		return 0
+	}
+	x, _ := p.Position()
+	return min(max((x-40)/80+1, 1), 3)
+}
+
+func (f *FishingMode) Draw(screen *ebiten.Image) {
+	pixeltext.Draw(screen, fmt.Sprintf("FISH: %d", f.Score), 4, 2, f.ctx.AccentStyle())
+	vector.FillRect(screen, 0, 180, ScreenWidth, 60, ColWater, false)
+	for i, a := range []input.Action{input.ActionCast1, input.ActionCast2, input.ActionCast3} {
+		sx := float32(80 * (i + 1))
+		label := f.ctx.Actions.Binding(a).String()
+		pixeltext.Draw(screen, label, float64(sx), 220, f.ctx.TextStyle().WithAlign(pixeltext.Center))
+		if f.TargetSpot == i+1 {
+			vector.FillCircle(screen, sx, 200, 10, ColFishShadow, true)
+		}
+	}
+	if f.State != FishingIdle {
+		bx, by := float32(f.BobberX), float32(f.BobberY)
+		if f.State == FishingReeling {
+			by += float32(math.Sin(float64(f.ctx.Tick)*0.8) * 5)
+		}
+		vector.StrokeLine(screen, 160, 140, bx, by, 1, color.White, false)
+		vector.FillCircle(screen, bx, by, 3, f.ctx.AccentColor, false)
+		if f.State == FishingReeling {
+			vector.FillRect(screen, 110, 120, 100, 10, colReelTrack, false)
+			vector.FillRect(screen, 110, 120, float32(f.ReelProgress), 10, f.ctx.AccentColor, false)
+		}
+	}
+	if f.panda != nil {
+		f.panda.Draw(screen)
+	} else {
+		pandaFishing.Draw(screen, 160, 140, nil)
+	}
+}
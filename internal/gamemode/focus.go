@@ -1,87 +1,264 @@
 package gamemode
 
 import (
-    "fmt"
-    "image/color"
-    "time"
-
-    "github.com/hajimehoshi/ebiten/v2"
-    "github.com/hajimehoshi/ebiten/v2/text/v2" 
-    // Note: If text/v2 is not found, use "github.com/hajimehoshi/ebiten/v2/text"
-    // and standard Go font packages. For simplicity, we'll use DebugPrint first.
-    "github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/entity"
+	"panda/internal/pixeltext"
+	"panda/internal/pomodoro"
+	"panda/internal/sprites"
+	"panda/internal/ui"
 )
 
 type FocusState int
 
 const (
-    FocusIdle    FocusState = iota // Waiting to start
-    FocusRunning                   // Timer ticking
-    FocusBreak                     // Short break
+	FocusIdle    FocusState = iota // Waiting to start a work session
+	FocusRunning                   // Work session ticking (or paused)
+	FocusBreak                     // Short or long break
 )
 
+// GopherState tracks the gopher who visits at the end of a session.
+type GopherState int
+
+const (
+	GopherAway    GopherState = iota
+	GopherNear                // Last 10% of the session
+	GopherArrived             // Session done, blowing a kiss
+)
+
+// focusClock is where the status and countdown are drawn.
+var focusClock = image.Rect(ScreenWidth/2-60, 18, ScreenWidth/2+60, 52)
+
+// FocusMode is the screen for the shared Pomodoro timer (ctx.Timer). Its
+// controls are ui buttons along the bottom, each with a hotkey, and the
+// clock can be clicked to start or pause:
+//
+//	SPACE start/pause/resume   S skip phase   R reset cycle (hold: no prompt)
+//	A toggle auto-start        work length slider (idle)
+//	3/4 go fishing / play Panda-Man during a break
 type FocusMode struct {
-    State       FocusState
-    Duration    time.Duration // Target time (e.g., 25 mins)
-    TimeLeft    time.Duration
-    LastUpdate  time.Time
+	base
+	ctx   *Context
+	ui    *ui.UI
+	panda *entity.Panda // Nil if the sheet didn't load
+
+	Gopher       GopherState
+	KissProgress float64
+
+	confirmReset bool // The "reset the cycle?" dialog is open
 }
 
-func NewFocusMode() *FocusMode {
-    return &FocusMode{
-        State:    FocusIdle,
-        Duration: 25 * time.Minute,
-        TimeLeft: 25 * time.Minute,
-    }
+func NewFocusMode(ctx *Context) *FocusMode {
+	return &FocusMode{ctx: ctx, ui: ctx.NewUI(), panda: newPanda(160, 120)}
 }
 
-func (f *FocusMode) Update() {
-    now := time.Now()
-
-    // Handle State Logic
-    switch f.State {
-    case FocusIdle:
-        // Press SPACE to start timer
-        if ebiten.IsKeyPressed(ebiten.KeySpace) {
-            f.State = FocusRunning
-            f.LastUpdate = now
-        }
-
-    case FocusRunning:
-        // Calculate time passed since last frame
-        dt := now.Sub(f.LastUpdate)
-        f.LastUpdate = now
-        
-        f.TimeLeft -= dt
-        
-        // Timer Finished?
-        if f.TimeLeft <= 0 {
-            f.State = FocusBreak
-            f.TimeLeft = 5 * time.Minute // Set break time
-        }
-    }
+// State maps the timer onto the three screens the focus mode shows.
+func (f *FocusMode) State() FocusState {
+	t := f.ctx.Timer
+	switch {
+	case t.Phase.IsBreak():
+		return FocusBreak
+	case t.Status == pomodoro.StatusStopped:
+		return FocusIdle
+	}
+	return FocusRunning
+}
+
+func (f *FocusMode) Exit() { f.confirmReset = false }
+
+func (f *FocusMode) Update() error {
+	t := f.ctx.Timer
+	now := f.ctx.Clock.Now()
+	u := f.ui
+	u.Begin(f.ctx.UITheme())
+
+	u.Column(100, 56, 120)
+	u.Progress("", 1-t.Fraction())
+
+	// The clock itself is a big start/pause button for touchscreens
+	if p := f.ctx.Pointer; p.JustPressed() && p.In(focusClock) && !f.confirmReset {
+		t.Toggle(now)
+	}
+
+	// Cycle Controls
+	u.Row(4, 206)
+	start := "Start [SPACE]"
+	switch t.Status {
+	case pomodoro.StatusRunning:
+		start = "Pause [SPACE]"
+	case pomodoro.StatusPaused:
+		start = "Resume [SPACE]"
+	}
+	if u.Button(start, ebiten.KeySpace) {
+		t.Toggle(now)
+	}
+	if u.Button("Skip [S]", ebiten.KeyS) {
+		t.Skip(now)
+	}
+	if u.Button("Reset [R]", ebiten.KeyR) {
+		// Throwing away a session in progress needs a second thought
+		if t.Status == pomodoro.StatusStopped {
+			t.Reset(now)
+		} else {
+			f.confirmReset = true
+		}
+	}
+	if u.Toggle("Auto [A]", &f.ctx.Settings.Focus.AutoStart, ebiten.KeyA) {
+		f.saveConfig()
+	}
+
+	switch f.State() {
+	case FocusIdle:
+		u.Row(4, 224)
+		if u.Slider("Work", &f.ctx.Settings.Focus.WorkMinutes, 5, 90, 5, "%dm") {
+			f.saveConfig()
+		}
+
+	case FocusBreak:
+		// Reward menu
+		u.Row(ScreenWidth/2-73, 188)
+		if u.Button("[3] Fishing", ebiten.Key3) {
+			f.ctx.Scenes.Switch(ModeFishing)
+		}
+		if u.Button("[4] Pacman", ebiten.Key4) {
+			f.ctx.Scenes.Switch(ModePacman)
+		}
+	}
+
+	// Holding R resets without asking
+	if f.ctx.Keys.LongPressed(ebiten.KeyR) {
+		t.Reset(now)
+		f.confirmReset = false
+	}
+	if f.confirmReset {
+		switch u.Modal("RESET", "Stop this session and start the cycle over?", "Reset", "Cancel") {
+		case 0:
+			t.Reset(now)
+			f.confirmReset = false
+		case 1:
+			f.confirmReset = false
+		}
+	}
+	u.End()
+
+	f.updateGopher()
+	f.updatePanda()
+	return nil
+}
+
+// The panda types through a work session, snacks on a break and dozes
+// while the timer is paused.
+func (f *FocusMode) updatePanda() {
+	if f.panda == nil {
+		return
+	}
+	t := f.ctx.Timer
+	switch {
+	case t.Phase.IsBreak():
+		f.panda.SetState(entity.PandaEating)
+	case t.Status == pomodoro.StatusRunning:
+		f.panda.SetState(entity.PandaTyping)
+	case t.Status == pomodoro.StatusPaused:
+		f.panda.SetState(entity.PandaSleeping)
+	default:
+		f.panda.SetState(entity.PandaIdle)
+	}
+	f.panda.Update()
+}
+
+// The gopher wanders in for the last 10% of a work session and stays to
+// blow a kiss for the break that follows.
+func (f *FocusMode) updateGopher() {
+	t := f.ctx.Timer
+	switch {
+	case t.Phase.IsBreak():
+		f.Gopher = GopherArrived
+	case t.Status != pomodoro.StatusStopped && t.Fraction() <= 0.10:
+		f.Gopher = GopherNear
+	default:
+		f.Gopher = GopherAway
+	}
+
+	if f.Gopher != GopherArrived {
+		f.KissProgress = 0
+	} else if f.KissProgress < 1.0 {
+		f.KissProgress += 0.01
+	}
+}
+
+func (f *FocusMode) saveConfig() {
+	f.ctx.Timer.SetConfig(f.ctx.Settings.Focus)
+	if f.ctx.SaveSettings != nil {
+		f.ctx.SaveSettings()
+	}
 }
 
 func (f *FocusMode) Draw(screen *ebiten.Image) {
-    // Simple UI for now
-    var status string
-    var timeStr string
-
-    // Format Duration: "25:00"
-    minutes := int(f.TimeLeft.Minutes())
-    seconds := int(f.TimeLeft.Seconds()) % 60
-    timeStr = fmt.Sprintf("%02d:%02d", minutes, seconds)
-
-    switch f.State {
-    case FocusIdle:
-        status = "PRESS SPACE TO FOCUS"
-    case FocusRunning:
-        status = "FOCUSED..."
-    case FocusBreak:
-        status = "TAKE A BREAK!"
-    }
-
-    // Render (Debug Print for now, we will add fancy fonts later)
-    msg := fmt.Sprintf("%s\n\n%s", status, timeStr)
-    ebitenutil.DebugPrintAt(screen, msg, 120, 150)
-}
\ No newline at end of file
+	t := f.ctx.Timer
+	var status string
+
+	switch t.Phase {
+	case pomodoro.PhaseWork:
+		status = fmt.Sprintf("FOCUS #%d", t.Sessions+1)
+	case pomodoro.PhaseShortBreak:
+		status = "TAKE A BREAK!"
+	case pomodoro.PhaseLongBreak:
+		status = "LONG BREAK!"
+	}
+	switch t.Status {
+	case pomodoro.StatusPaused:
+		status += " (PAUSED)"
+	}
+
+	// Format Duration: "25:00"
+	minutes := int(t.Remaining.Minutes())
+	seconds := int(t.Remaining.Seconds()) % 60
+	msg := fmt.Sprintf("%s\n%02d:%02d  %s", status, minutes, seconds, f.sessionDots())
+	pixeltext.Draw(screen, msg, ScreenWidth/2, 20, f.ctx.TextStyle().WithAlign(pixeltext.Center))
+
+	if f.panda != nil {
+		f.panda.Draw(screen)
+	} else {
+		paws := 0
+		if f.State() == FocusRunning && t.Status == pomodoro.StatusRunning && f.ctx.Tick%10 < 5 {
+			paws = 1
+		}
+		pandaTyping[paws].Draw(screen, 160, 120, nil)
+	}
+
+	f.drawGopher(screen)
+	f.ui.Draw(screen)
+}
+
+func (f *FocusMode) drawGopher(screen *ebiten.Image) {
+	if f.Gopher == GopherAway {
+		return
+	}
+	gx := 240.0
+	gy := 120 + math.Sin(float64(f.ctx.Tick)*0.08)*5
+	sprites.Gopher.Draw(screen, gx, gy, nil)
+	if f.Gopher == GopherArrived {
+		progress := f.KissProgress
+		hx := gx - (progress * 60)
+		hy := gy - 10 - (math.Sin(progress*math.Pi) * 20)
+		sprites.Heart.Draw(screen, hx, hy, nil)
+		pixeltext.Draw(screen, "GREAT JOB!", ScreenWidth/2, 172, f.ctx.AccentStyle().WithAlign(pixeltext.Center))
+	}
+}
+
+// sessionDots shows progress towards the next long break, e.g. "**..".
+func (f *FocusMode) sessionDots() string {
+	t := f.ctx.Timer
+	every := t.Config.LongBreakEvery
+	done := t.Sessions % every
+	if t.Phase == pomodoro.PhaseLongBreak {
+		done = every
+	}
+	return strings.Repeat("*", done) + strings.Repeat(".", every-done)
+}
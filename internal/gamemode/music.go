@@ -0,0 +1,155 @@
+package gamemode
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/audio"
+	"panda/internal/chiptune"
+	"panda/internal/pixeltext"
+	"panda/internal/ui"
+)
+
+// MusicMode is a chiptune jukebox: pick a song from the playlist and the
+// panda bobs along while the tracker rows scroll by. A finished song
+// moves on to the next.
+type MusicMode struct {
+	base
+	ctx *Context
+	ui  *ui.UI
+
+	Songs    []*chiptune.Song
+	Selected int
+	Playing  int // Index into Songs; -1 when stopped
+}
+
+func NewMusicMode(ctx *Context) *MusicMode {
+	songs, err := chiptune.Songs()
+	if err != nil {
+		log.Printf("music: %v", err)
+	}
+	return &MusicMode{ctx: ctx, ui: ctx.NewUI(), Songs: songs, Playing: -1}
+}
+
+func (m *MusicMode) Exit() { m.stop() }
+
+func (m *MusicMode) Update() error {
+	// A song that ran out hands over to the next one. Playing is only
+	// set once a song has started, so one that can't doesn't end here
+	// every tick.
+	if m.Playing >= 0 && !m.ctx.Audio.MusicPlaying() {
+		m.play((m.Playing + 1) % len(m.Songs))
+	}
+
+	u := m.ui
+	u.Begin(m.ctx.UITheme())
+	u.Column(4, 2, 150)
+	u.LabelStyle("MUSIC", m.ctx.AccentStyle())
+	u.Spacer(14)
+	titles := make([]string, len(m.Songs))
+	for i, s := range m.Songs {
+		titles[i] = "  " + s.Title
+		if i == m.Playing {
+			titles[i] = "~ " + s.Title
+		}
+	}
+	if u.List(titles, &m.Selected) {
+		m.play(m.Selected)
+	}
+
+	if len(m.Songs) > 0 {
+		u.Row(4, 130)
+		if u.Button("[P]rev", ebiten.KeyP) {
+			m.play((m.current() + len(m.Songs) - 1) % len(m.Songs))
+		}
+		if m.Playing >= 0 {
+			if u.Button("Stop [SPACE]", ebiten.KeySpace) {
+				m.stop()
+			}
+		} else if u.Button("Play [SPACE]", ebiten.KeySpace) {
+			m.play(m.Selected)
+		}
+		if u.Button("[N]ext", ebiten.KeyN) {
+			m.play((m.current() + 1) % len(m.Songs))
+		}
+	}
+
+	if m.Playing >= 0 {
+		s := m.Songs[m.Playing]
+		pos := m.ctx.Audio.MusicPosition()
+		u.Column(4, 180, 150)
+		u.Progress(minSec(pos)+" / "+minSec(s.Duration()), float64(pos)/float64(s.Duration()))
+	}
+	u.End()
+	return nil
+}
+
+// current is the song Prev and Next step from: the one playing, else the
+// selection.
+func (m *MusicMode) current() int {
+	if m.Playing >= 0 {
+		return m.Playing
+	}
+	return m.Selected
+}
+
+// play starts song i. If it won't start (no audio device, or no player
+// for it) the jukebox stops.
+func (m *MusicMode) play(i int) {
+	m.Selected = i
+	if !m.ctx.Audio.PlayMusic(chiptune.NewStream(m.Songs[i], audio.SampleRate)) {
+		m.stop()
+		return
+	}
+	m.Playing = i
+}
+
+func (m *MusicMode) stop() {
+	m.Playing = -1
+	m.ctx.Audio.StopMusic()
+}
+
+func minSec(d time.Duration) string {
+	s := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+func (m *MusicMode) Draw(screen *ebiten.Image) {
+	m.ui.Draw(screen)
+
+	bob := 0.0
+	if m.Playing >= 0 {
+		s := m.Songs[m.Playing]
+		pos := m.ctx.Audio.MusicPosition()
+		m.drawRows(screen, s, pos)
+		// A hop on every beat
+		beat := pos.Seconds() * float64(s.Tempo) / 60
+		bob = math.Abs(math.Sin(math.Pi*beat)) * 6
+	}
+	pandaPlain.Draw(screen, 245, 170-bob, nil)
+}
+
+// drawRows is a little tracker view: the row playing and two either
+// side, one column per channel.
+func (m *MusicMode) drawRows(screen *ebiten.Image, s *chiptune.Song, pos time.Duration) {
+	order, row := s.At(pos)
+	rows := s.Patterns[s.Order[order]].Rows
+	head := fmt.Sprintf("PATTERN %d/%d", order+1, len(s.Order))
+	pixeltext.Draw(screen, head, 170, 24, m.ctx.AccentStyle())
+	for i := -2; i <= 2; i++ {
+		r := row + i
+		if r < 0 || r >= len(rows) {
+			continue
+		}
+		line := fmt.Sprintf("%02d %v %v %v %v", r, rows[r][0], rows[r][1], rows[r][2], rows[r][3])
+		st := m.ctx.TextStyle()
+		if i == 0 {
+			st = m.ctx.AccentStyle()
+		}
+		pixeltext.Draw(screen, line, 170, float64(40+pixeltext.LineHeight*(i+2)), st)
+	}
+}
@@ -0,0 +1,600 @@
+package gamemode
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"panda/internal/audio"
+	"panda/internal/ghost"
+	"panda/internal/input"
+	"panda/internal/maze"
+	"panda/internal/pixeltext"
+	"panda/internal/sprites"
+	"panda/internal/ui"
+)
+
+// TileSize is the on-screen size of a tile; boards too big for the
+// screen at this size are drawn smaller.
+const TileSize = 16
+
+// pacmanField is the screen area boards are centred in; the HUD line
+// sits below it.
+var pacmanField = image.Rect(0, 0, ScreenWidth, ScreenHeight-16)
+
+// Scoring
+const (
+	pointsDot    = 10
+	pointsPellet = 50
+	pointsGhost  = 200 // Doubles for each further gopher eaten on one pellet
+	pointsLife   = 500 // Bonus per life left when a board is cleared
+	startLives   = 3
+)
+
+// fruitPoints is what the bamboo is worth on each level; later levels
+// keep the last value.
+var fruitPoints = []int{100, 300, 500, 700, 1000}
+
+// readyTicks is the pause before play starts, and after losing a life.
+const readyTicks = 2 * input.TPS
+
+// playerTicks is how long the panda takes to cross a tile. The gophers
+// never go quite this fast.
+const playerTicks = 6
+
+// Endless boards: their size, and how many seeds a run picks from, so
+// the seed on the ready screen stays short enough to note down.
+const (
+	endlessW, endlessH = 20, 13
+	endlessSeeds       = 100000
+)
+
+// touchDistance is how close, in tiles, a gopher has to come to touch
+// the panda.
+const touchDistance = 0.5
+
+// PacmanBreakdown is where a board's points came from, shown when it's
+// cleared.
+type PacmanBreakdown struct {
+	Dots, Pellets, Ghosts, Fruit int // How many of each were eaten
+	GhostPoints, FruitPoints     int
+	LivesBonus                   int
+}
+
+func (b PacmanBreakdown) Total() int {
+	return b.Dots*pointsDot + b.Pellets*pointsPellet + b.GhostPoints + b.FruitPoints + b.LivesBonus
+}
+
+// PacmanMode is Panda-Man: eat the bamboo dots, dodge the gophers. The
+// panda keeps walking until it meets a wall; a direction (action or
+// swipe) is remembered and taken at the first opening. A power pellet
+// turns the gophers blue for a while, and each one eaten is worth twice
+// the last. Clearing a board moves on to the next level of the campaign,
+// where the gophers are quicker and less easily scared. In endless mode
+// every board is generated and there is always another.
+type PacmanMode struct {
+	base
+	ctx *Context
+	ui  *ui.UI
+
+	Levels          []*maze.Level  // The campaign, in order
+	Level           int            // Index into Levels; in endless mode, boards cleared
+	Endless         bool           // Generated boards instead of the campaign
+	Seed            int64          // Endless: level n is generated from Seed+n
+	Maze            *maze.Level    // The board being played, dots and all
+	Player          maze.Point     // Tile the panda is on, or leaving
+	PlayerDir       maze.Point     // Heading; kept when stopped, for the ghosts that aim ahead
+	PlayerWant      maze.Point     // Buffered turn, taken at the next opening
+	PlayerStep      int            // Ticks into crossing to the next tile
+	Ghosts          []*ghost.Ghost // One per G on the board
+	GhostModes      *ghost.Timer
+	GhostSpeedDelay int // Ticks per ghost step
+	Score           int
+	Lives           int
+	Ready           int // Ticks until play (re)starts
+	FruitTicks      int // How much longer the bamboo stays out; 0 when it isn't
+	Breakdown       PacmanBreakdown
+	GameOver, Win   bool // Win: this board is cleared
+
+	levelScore int // Score when the board started, restored on a retry
+	dotsTotal  int // Dots on the board at the start, to time the fruit
+	chain      int // Points for the next gopher eaten during this fright
+}
+
+func NewPacmanMode(ctx *Context) *PacmanMode {
+	p := &PacmanMode{ctx: ctx, ui: ctx.NewUI(), Levels: campaign(), Lives: startLives}
+	p.Reset()
+	return p
+}
+
+// NewEndlessPacmanMode is Panda-Man on generated boards; each visit is a
+// new run from a new seed. The campaign is kept to fall back on.
+func NewEndlessPacmanMode(ctx *Context) *PacmanMode {
+	p := &PacmanMode{ctx: ctx, ui: ctx.NewUI(), Levels: campaign(), Endless: true, Lives: startLives}
+	p.Reset()
+	return p
+}
+
+// campaign loads the built-in levels, logging any that are broken.
+func campaign() []*maze.Level {
+	levels, err := maze.Campaign()
+	if err != nil {
+		log.Printf("pacman: %v", err)
+	}
+	return levels
+}
+
+// Enter starts the campaign over every time the scene is opened; an
+// endless run starts from a new seed.
+func (p *PacmanMode) Enter() { p.restart() }
+
+// restart goes back to the first level with a new score and lives, and
+// in endless mode a new seed.
+func (p *PacmanMode) restart() {
+	if p.Endless {
+		p.Seed = p.ctx.Rand.Int63n(endlessSeeds)
+	}
+	p.Level, p.Score, p.Lives = 0, 0, startLives
+	p.Reset()
+}
+
+// Reset (re)starts the current level.
+func (p *PacmanMode) Reset() {
+	if p.Maze = p.board(); p.Maze == nil {
+		return
+	}
+	p.dotsTotal = p.Maze.Dots()
+	p.levelScore = p.Score
+	p.Breakdown = PacmanBreakdown{}
+	p.GameOver, p.Win = false, false
+
+	// Difficulty Scaling: later levels, and more campaign wins today,
+	// speed the gopher up
+	delay := 30 - p.Level*4
+	if !p.Endless {
+		delay -= p.ctx.Stats.PacmanWinsToday * 2
+	}
+	if delay < playerTicks+1 {
+		delay = playerTicks + 1
+	}
+	p.GhostSpeedDelay = delay
+	p.respawn()
+}
+
+// board is a fresh copy of the current level: the campaign's, or a
+// generated one with more gophers as the boards go by. A board that
+// can't be generated is logged and a campaign board played instead.
+func (p *PacmanMode) board() *maze.Level {
+	if p.Endless {
+		l, err := maze.Generate(p.Seed+int64(p.Level), endlessW, endlessH, min(2+p.Level/2, 4))
+		if err == nil {
+			return l
+		}
+		log.Printf("pacman: %v", err)
+		if len(p.Levels) == 0 {
+			return nil
+		}
+		return p.Levels[p.Level%len(p.Levels)].Clone()
+	}
+	if p.Level >= len(p.Levels) {
+		return nil
+	}
+	return p.Levels[p.Level].Clone()
+}
+
+// respawn puts everyone back at the start, dots as they are.
+func (p *PacmanMode) respawn() {
+	p.Player = p.Maze.Player
+	p.PlayerDir, p.PlayerWant, p.PlayerStep = maze.Point{}, maze.Point{}, 0
+	p.FruitTicks = 0
+	p.Ready = readyTicks
+
+	// The ghosts take turns in the personalities and leave home two
+	// seconds apart; scatter breaks shorten as the levels go on
+	p.Ghosts = p.Ghosts[:0]
+	for i, home := range p.Maze.Ghosts {
+		g := ghost.New(ghost.Personality(i), home, p.Maze, p.GhostSpeedDelay)
+		g.Wait = i * 2 * input.TPS
+		p.Ghosts = append(p.Ghosts, g)
+	}
+	p.GhostModes = ghost.NewTimer(ghost.ArcadeSchedule(max(7-p.Level, 3)))
+}
+
+// Finished reports whether the last level has been cleared; an endless
+// run never is.
+func (p *PacmanMode) Finished() bool {
+	return p.Win && !p.Endless && p.Level == len(p.Levels)-1
+}
+
+// advance moves on after a board ends: a retry after losing in the
+// campaign, the next level after winning, or the first again after the
+// campaign. Losing ends an endless run; the next one has a new seed.
+func (p *PacmanMode) advance() {
+	switch {
+	case p.GameOver && !p.Endless:
+		p.Score, p.Lives = p.levelScore, startLives
+	case p.GameOver, p.Finished():
+		p.restart()
+		return
+	default:
+		p.Level++
+	}
+	p.Reset()
+}
+
+func (p *PacmanMode) Update() error {
+	if p.Maze == nil {
+		return nil
+	}
+	if p.Win {
+		p.updateBreakdown()
+		return nil
+	}
+	if p.GameOver {
+		if p.ctx.Actions.JustPressed(input.ActionConfirm) || p.ctx.Pointer.JustPressed() {
+			p.advance()
+		}
+		return nil
+	}
+	if p.Ready > 0 {
+		p.Ready--
+		return nil
+	}
+
+	// Where everyone was, to catch gophers passing through the panda
+	from := p.positions()
+	p.updatePlayer()
+	if p.Win {
+		return nil
+	}
+	if p.FruitTicks > 0 {
+		p.FruitTicks--
+	}
+	p.updateGhosts()
+	p.collide(from)
+	return nil
+}
+
+// steer buffers the direction asked for this tick, if any.
+func (p *PacmanMode) steer() {
+	if dx, dy := p.ctx.Pointer.Swipe(); dx != 0 || dy != 0 {
+		p.PlayerWant = maze.Point{X: max(min(dx, 1), -1), Y: max(min(dy, 1), -1)}
+	}
+	for _, d := range []struct {
+		act input.Action
+		dir maze.Point
+	}{
+		{input.ActionLeft, maze.Point{X: -1}},
+		{input.ActionRight, maze.Point{X: 1}},
+		{input.ActionUp, maze.Point{Y: -1}},
+		{input.ActionDown, maze.Point{Y: 1}},
+	} {
+		if p.ctx.Actions.JustPressed(d.act) {
+			p.PlayerWant = d.dir
+		}
+	}
+}
+
+// updatePlayer walks the panda a tick's worth: turning when it is on a
+// tile and the buffered way is open, stopping at walls, and turning back
+// at any moment.
+func (p *PacmanMode) updatePlayer() {
+	p.steer()
+	back := maze.Point{X: -p.PlayerDir.X, Y: -p.PlayerDir.Y}
+	switch {
+	case p.PlayerStep == 0:
+		if p.open(p.PlayerWant) {
+			p.PlayerDir = p.PlayerWant
+		}
+		if !p.open(p.PlayerDir) {
+			return // Against a wall
+		}
+	case p.PlayerWant == back:
+		p.Player, _ = p.Maze.Wrap(p.Player.Add(p.PlayerDir))
+		p.PlayerDir = back
+		p.PlayerStep = playerTicks - p.PlayerStep
+	}
+	if p.PlayerStep++; p.PlayerStep == playerTicks {
+		p.PlayerStep = 0
+		p.Player, _ = p.Maze.Wrap(p.Player.Add(p.PlayerDir))
+		p.eat()
+	}
+}
+
+// open reports whether the panda can step from its tile in dir.
+func (p *PacmanMode) open(dir maze.Point) bool {
+	if dir == (maze.Point{}) {
+		return false
+	}
+	to, ok := p.Maze.Wrap(p.Player.Add(dir))
+	return ok && p.Maze.At(to) != maze.Wall
+}
+
+func (p *PacmanMode) updateGhosts() {
+	if calmed, switched := p.GhostModes.Update(); calmed || switched {
+		for _, g := range p.Ghosts {
+			g.Frightened = false
+			if switched {
+				g.Reverse()
+			}
+		}
+	}
+	target := ghost.Target{Player: p.Player, PlayerDir: p.PlayerDir, Chaser: p.Ghosts[0].Pos}
+	for _, g := range p.Ghosts {
+		g.Update(p.Maze, p.GhostModes.Mode(), target, p.ctx.Rand)
+	}
+}
+
+// eat clears whatever is on the tile the panda just reached.
+func (p *PacmanMode) eat() {
+	to := p.Player
+	if p.FruitTicks > 0 && to == p.Maze.Player {
+		pts := fruitPoints[min(p.Level, len(fruitPoints)-1)]
+		p.FruitTicks = 0
+		p.Breakdown.Fruit++
+		p.Breakdown.FruitPoints += pts
+		p.addScore(pts)
+		p.ctx.Audio.Play(audio.SoundBite)
+	}
+	switch p.Maze.At(to) {
+	case maze.Dot:
+		p.Breakdown.Dots++
+		p.addScore(pointsDot)
+	case maze.Pellet:
+		p.Breakdown.Pellets++
+		p.addScore(pointsPellet)
+		p.frighten()
+	default:
+		return
+	}
+	p.Maze.Set(to, maze.Floor)
+	p.ctx.Audio.Play(audio.SoundDot)
+
+	left := p.Maze.Dots()
+	if eaten := p.dotsTotal - left; eaten == p.dotsTotal/3 || eaten == p.dotsTotal*2/3 {
+		p.FruitTicks = 10 * input.TPS
+	}
+	if left == 0 {
+		p.Win = true
+		p.Breakdown.LivesBonus = p.Lives * pointsLife
+		p.addScore(p.Breakdown.LivesBonus)
+		if p.Endless {
+			p.ctx.Stats.PacmanEndlessBest = max(p.ctx.Stats.PacmanEndlessBest, p.Level+1)
+		} else {
+			p.ctx.Stats.PacmanWinsToday++
+		}
+	}
+}
+
+// frighten turns every gopher that isn't already eaten blue; the fright
+// gets shorter level by level.
+func (p *PacmanMode) frighten() {
+	p.chain = pointsGhost
+	p.GhostModes.Frighten(max(6-p.Level, 2) * input.TPS)
+	for _, g := range p.Ghosts {
+		if !g.Eaten {
+			g.Frightened = true
+			g.Reverse()
+		}
+	}
+}
+
+// pos is a point on the board in tiles, between tile centres while
+// someone is on the move.
+type pos struct{ x, y float64 }
+
+func glide(at, dir maze.Point, frac float64) pos {
+	return pos{float64(at.X) + float64(dir.X)*frac, float64(at.Y) + float64(dir.Y)*frac}
+}
+
+// positions returns the panda's position followed by each gopher's.
+func (p *PacmanMode) positions() []pos {
+	out := []pos{glide(p.Player, p.PlayerDir, float64(p.PlayerStep)/playerTicks)}
+	for _, g := range p.Ghosts {
+		out = append(out, glide(g.Pos, g.Dir, g.Offset()))
+	}
+	return out
+}
+
+// collide settles gophers touching the panda: it eats them or they eat
+// it. Each pair counts as touching if they came within touchDistance at
+// any point during the tick, so they can't slip through one another
+// between ticks.
+func (p *PacmanMode) collide(from []pos) {
+	to := p.positions()
+	for i, g := range p.Ghosts {
+		if !p.met(from[0], to[0], from[i+1], to[i+1]) {
+			continue
+		}
+		if g.Frightened {
+			g.Eat()
+			p.Breakdown.Ghosts++
+			p.Breakdown.GhostPoints += p.chain
+			p.addScore(p.chain)
+			p.chain *= 2
+			p.ctx.Stats.GhostsEaten++
+			p.ctx.Audio.Play(audio.SoundBite)
+		} else if g.Harmful() {
+			p.loseLife()
+			return
+		}
+	}
+}
+
+// met reports whether two things moving in straight lines over a tick,
+// a0 to a1 and b0 to b1, come within touchDistance of each other. The
+// gap at the end is the gap at the start plus how it changed, so one
+// that wraps round half the board in between doesn't look like a pass.
+func (p *PacmanMode) met(a0, a1, b0, b1 pos) bool {
+	r0 := p.wrapGap(pos{a0.x - b0.x, a0.y - b0.y})
+	dr := p.wrapGap(pos{(a1.x - b1.x) - (a0.x - b0.x), (a1.y - b1.y) - (a0.y - b0.y)})
+	r1 := pos{r0.x + dr.x, r0.y + dr.y}
+	// Closest approach of r0 + t(r1-r0) to the origin, t in [0, 1]
+	dx, dy := r1.x-r0.x, r1.y-r0.y
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = min(max(-(r0.x*dx+r0.y*dy)/l, 0), 1)
+	}
+	cx, cy := r0.x+t*dx, r0.y+t*dy
+	return cx*cx+cy*cy < touchDistance*touchDistance
+}
+
+// wrapGap shortens a gap that is quicker to cross through a tunnel.
+func (p *PacmanMode) wrapGap(d pos) pos {
+	w, h := float64(p.Maze.W), float64(p.Maze.H)
+	switch {
+	case d.x > w/2:
+		d.x -= w
+	case d.x < -w/2:
+		d.x += w
+	}
+	switch {
+	case d.y > h/2:
+		d.y -= h
+	case d.y < -h/2:
+		d.y += h
+	}
+	return d
+}
+
+func (p *PacmanMode) loseLife() {
+	if p.Lives--; p.Lives <= 0 {
+		p.GameOver = true
+		return
+	}
+	p.respawn()
+}
+
+// addScore also keeps the all-time high score.
+func (p *PacmanMode) addScore(n int) {
+	p.Score += n
+	p.ctx.Stats.PacmanHighScore = max(p.ctx.Stats.PacmanHighScore, p.Score)
+}
+
+// updateBreakdown shows the cleared board's points until the player
+// moves on.
+func (p *PacmanMode) updateBreakdown() {
+	title, next := fmt.Sprintf("LEVEL %d CLEAR!", p.Level+1), "Next level"
+	if p.Finished() {
+		title, next = "CAMPAIGN CLEAR!", "Play again"
+	}
+	u := p.ui
+	u.Begin(p.ctx.UITheme())
+	if u.Modal(title, p.breakdownText(), next) == 0 {
+		p.advance()
+	}
+	u.End()
+}
+
+func (p *PacmanMode) breakdownText() string {
+	b := p.Breakdown
+	return fmt.Sprintf(
+		"Dots     %3d x %-3d %6d\n"+
+			"Pellets  %3d x %-3d %6d\n"+
+			"Gophers  %3d       %6d\n"+
+			"Bamboo   %3d       %6d\n"+
+			"Lives    %3d x %-3d %6d\n"+
+			"Board              %6d\n"+
+			"Score              %6d",
+		b.Dots, pointsDot, b.Dots*pointsDot,
+		b.Pellets, pointsPellet, b.Pellets*pointsPellet,
+		b.Ghosts, b.GhostPoints,
+		b.Fruit, b.FruitPoints,
+		p.Lives, pointsLife, b.LivesBonus,
+		b.Total(), p.Score)
+}
+
+// layout fits the board into pacmanField: the tile size and where the
+// top-left tile goes.
+func (p *PacmanMode) layout() (tile, x0, y0 int) {
+	tile = min(TileSize, pacmanField.Dx()/p.Maze.W, pacmanField.Dy()/p.Maze.H)
+	x0 = pacmanField.Min.X + (pacmanField.Dx()-tile*p.Maze.W)/2
+	y0 = pacmanField.Min.Y + (pacmanField.Dy()-tile*p.Maze.H)/2
+	return tile, x0, y0
+}
+
+func (p *PacmanMode) Draw(screen *ebiten.Image) {
+	if p.Maze == nil {
+		pixeltext.Draw(screen, "NO LEVELS", ScreenWidth/2, 100, p.ctx.AccentStyle().WithAlign(pixeltext.Center))
+		return
+	}
+	tile, x0, y0 := p.layout()
+	center := func(at pos) (float64, float64) {
+		t := float64(tile)
+		return float64(x0) + at.x*t + t/2, float64(y0) + at.y*t + t/2
+	}
+	for y := range p.Maze.H {
+		for x := range p.Maze.W {
+			px, py := float32(x0+x*tile), float32(y0+y*tile)
+			cx, cy := center(pos{float64(x), float64(y)})
+			switch p.Maze.At(maze.Point{X: x, Y: y}) {
+			case maze.Wall:
+				vector.FillRect(screen, px, py, float32(tile), float32(tile), ColMazeWall, false)
+			case maze.Dot:
+				vector.FillCircle(screen, float32(cx), float32(cy), float32(tile)/8, ColDot, true)
+			case maze.Pellet:
+				vector.FillCircle(screen, float32(cx), float32(cy), float32(tile)/4, ColDot, true)
+			}
+		}
+	}
+	opts := &sprites.DrawOptions{Scale: float64(tile) / TileSize}
+	if p.FruitTicks > 0 {
+		fx, fy := center(glide(p.Maze.Player, maze.Point{}, 0))
+		sprites.Bamboo.Draw(screen, fx, fy, opts)
+	}
+	// Everyone is drawn part way between tiles as they glide
+	at := p.positions()
+	ppx, ppy := center(at[0])
+	sprites.PandaHead.Draw(screen, ppx, ppy, opts)
+	for i, g := range p.Ghosts {
+		gpx, gpy := center(at[i+1])
+		p.ghostSprite(g).Draw(screen, gpx, gpy, opts)
+	}
+	p.drawHUD(screen)
+
+	switch {
+	case p.Win:
+		p.ui.Draw(screen)
+	case p.GameOver:
+		again := p.ctx.Actions.Binding(input.ActionConfirm).String()
+		pixeltext.Draw(screen, "GAME OVER - retry ("+again+")", ScreenWidth/2, 100, p.ctx.AccentStyle().WithAlign(pixeltext.Center))
+	case p.Ready > 0:
+		pixeltext.Draw(screen, p.Maze.Name+"\nREADY!", ScreenWidth/2, 92, p.ctx.AccentStyle().WithAlign(pixeltext.Center))
+	}
+}
+
+// ghostSprite picks how g looks: blue when frightened, blinking in the
+// last two seconds of the fright, and just eyes once eaten.
+func (p *PacmanMode) ghostSprite(g *ghost.Ghost) *sprites.Sprite {
+	switch {
+	case g.Eaten:
+		return sprites.GopherEyes
+	case g.Frightened:
+		if left := p.GhostModes.Frightened(); left < 2*input.TPS && left/8%2 == 0 {
+			return sprites.GopherFlash
+		}
+		return sprites.GopherScared
+	}
+	return sprites.GopherHead
+}
+
+// drawHUD is the line under the board: level and lives, high score,
+// score.
+func (p *PacmanMode) drawHUD(screen *ebiten.Image) {
+	y := float64(pacmanField.Max.Y)
+	level := fmt.Sprintf("LV %d/%d", p.Level+1, len(p.Levels))
+	if p.Endless {
+		level = fmt.Sprintf("LV %d", p.Level+1)
+	}
+	pixeltext.Draw(screen, level, 4, y, p.ctx.TextStyle())
+	for i := range p.Lives {
+		sprites.PandaHead.Draw(screen, 60+float64(i)*12, y+7, &sprites.DrawOptions{Scale: 0.5})
+	}
+	hi := fmt.Sprintf("HI %d", p.ctx.Stats.PacmanHighScore)
+	pixeltext.Draw(screen, hi, ScreenWidth/2+20, y, p.ctx.TextStyle().WithAlign(pixeltext.Center))
+	score := fmt.Sprintf("SCORE %d", p.Score)
+	pixeltext.Draw(screen, score, ScreenWidth-4, y, p.ctx.AccentStyle().WithAlign(pixeltext.Right))
+}
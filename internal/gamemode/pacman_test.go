@@ -0,0 +1,89 @@
+package gamemode
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"panda/internal/clock"
+	"panda/internal/input"
+	"panda/internal/maze"
+)
+
+// newTestContext is a headless context with default settings.
+func newTestContext() *Context {
+	ctx := NewContext(input.NewScript(), clock.NewManual(time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)), rand.New(rand.NewSource(1)))
+	ctx.ApplySettings()
+	return ctx
+}
+
+// Every seed an endless run can start from must give a board; Generate
+// validates it. Four gophers is the hardest case: the board is the same
+// whatever the count, with more starts to reach. Short runs sample the
+// seeds, spread evenly over the range.
+func TestEndlessSeeds(t *testing.T) {
+	step := int64(1)
+	if testing.Short() {
+		step = 97
+	}
+	for seed := int64(0); seed < endlessSeeds; seed += step {
+		if _, err := maze.Generate(seed, endlessW, endlessH, 4); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+func TestMet(t *testing.T) {
+	p := &PacmanMode{Maze: &maze.Level{W: 15, H: 3}} // Only the size matters
+	tests := []struct {
+		name           string
+		a0, a1, b0, b1 pos
+		want           bool
+	}{
+		{"passing head on", pos{3, 1}, pos{3.2, 1}, pos{3.3, 1}, pos{3.1, 1}, true},
+		{"following behind", pos{3, 1}, pos{3.2, 1}, pos{4, 1}, pos{4.1, 1}, false},
+		{"half the board apart", pos{8.4, 1}, pos{8.6, 1}, pos{1, 1}, pos{1.03, 1}, false},
+		{"through the tunnel", pos{0, 1}, pos{-0.2, 1}, pos{14.6, 1}, pos{14.5, 1}, true},
+	}
+	for _, tt := range tests {
+		if got := p.met(tt.a0, tt.a1, tt.b0, tt.b1); got != tt.want {
+			t.Errorf("%s: met = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGameOver(t *testing.T) {
+	tests := []struct {
+		name    string
+		endless bool
+		level   int // Afterwards
+		score   int
+		newSeed bool
+	}{
+		{"campaign retries the board", false, 2, 500, false},
+		{"endless starts a new run", true, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext()
+			p := NewPacmanMode(ctx)
+			if tt.endless {
+				p = NewEndlessPacmanMode(ctx)
+			}
+			p.Enter()
+			p.Level, p.Score = 2, 500
+			p.Reset() // The board starts with 500 points
+			seed := p.Seed
+			p.Score, p.Lives, p.GameOver = 900, 0, true
+
+			p.advance()
+			if p.Level != tt.level || p.Score != tt.score || p.Lives != startLives || p.GameOver {
+				t.Errorf("level %d, score %d, %d lives, game over %v; want level %d, score %d, %d lives",
+					p.Level, p.Score, p.Lives, p.GameOver, tt.level, tt.score, startLives)
+			}
+			if (p.Seed != seed) != tt.newSeed {
+				t.Errorf("seed %d -> %d, want a new one: %v", seed, p.Seed, tt.newSeed)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package gamemode
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/pixeltext"
+)
+
+// PlaceholderMode stands in for modes that haven't been built yet.
+type PlaceholderMode struct {
+	base
+	ctx   *Context
+	Title string
+}
+
+func NewPlaceholderMode(ctx *Context, title string) *PlaceholderMode {
+	return &PlaceholderMode{ctx: ctx, Title: title}
+}
+
+func (p *PlaceholderMode) Update() error { return nil }
+
+func (p *PlaceholderMode) Draw(screen *ebiten.Image) {
+	pixeltext.Draw(screen, "MODE: "+p.Title, ScreenWidth/2, 100, p.ctx.AccentStyle().WithAlign(pixeltext.Center))
+	pixeltext.Draw(screen, "(Coming Soon)", ScreenWidth/2, 116, p.ctx.TextStyle().WithAlign(pixeltext.Center))
+}
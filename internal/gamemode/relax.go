@@ -0,0 +1,83 @@
+package gamemode
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/anim"
+	"panda/internal/audio"
+	"panda/internal/entity"
+	"panda/internal/input"
+	"panda/internal/pixeltext"
+)
+
+const (
+	relaxNapTicks   = 20 * input.TPS // Left alone this long, the panda dozes off
+	relaxSnackTicks = 2 * input.TPS  // How long a snack lasts
+)
+
+// RelaxMode just lets the panda chill on screen. CONFIRM or a tap hands
+// it a snack; left alone for a while it naps.
+type RelaxMode struct {
+	base
+	ctx   *Context
+	panda *entity.Panda // Nil if the sheet didn't load
+
+	Idle  int // Ticks since the last key, button or tap
+	Snack int // Ticks of eating left
+}
+
+func NewRelaxMode(ctx *Context) *RelaxMode {
+	r := &RelaxMode{ctx: ctx, panda: newPanda(160, 140)}
+	if r.panda != nil {
+		r.panda.Anim.Subscribe(func(ev anim.Event) {
+			if ev.Name == "chomp" {
+				ctx.Audio.Play(audio.SoundBite)
+			}
+		})
+	}
+	return r
+}
+
+func (r *RelaxMode) Enter() { r.Idle, r.Snack = 0, 0 }
+
+func (r *RelaxMode) Update() error {
+	a, p := r.ctx.Actions, r.ctx.Pointer
+	if _, pushed := a.Pushed(); pushed || p.JustPressed() {
+		r.Idle = 0
+	} else {
+		r.Idle++
+	}
+	if a.JustPressed(input.ActionConfirm) || p.JustPressed() {
+		r.Snack = relaxSnackTicks
+	} else if r.Snack > 0 {
+		r.Snack--
+	}
+
+	if r.panda == nil {
+		return nil
+	}
+	switch {
+	case r.Snack > 0:
+		r.panda.SetState(entity.PandaEating)
+	case r.Idle >= relaxNapTicks:
+		r.panda.SetState(entity.PandaSleeping)
+	default:
+		r.panda.SetState(entity.PandaIdle)
+	}
+	r.panda.Update()
+	return nil
+}
+
+func (r *RelaxMode) Draw(screen *ebiten.Image) {
+	pixeltext.Draw(screen, "RELAX", 4, 2, r.ctx.AccentStyle())
+	hint := fmt.Sprintf("%s: snack", r.ctx.Actions.Binding(input.ActionConfirm))
+	pixeltext.Draw(screen, hint, ScreenWidth/2, 220, r.ctx.TextStyle().WithAlign(pixeltext.Center))
+	if r.panda != nil {
+		r.panda.Draw(screen)
+		return
+	}
+	pandaPlain.Draw(screen, 160, 140+math.Sin(float64(r.ctx.Tick)*0.05)*2, nil)
+}
@@ -0,0 +1,212 @@
+package gamemode
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"panda/internal/audio"
+	"panda/internal/input"
+	"panda/internal/ui"
+)
+
+// SettingsMode picks the color profile from a list; moving the selection
+// previews and saves it straight away. Its Controls page rebinds the
+// input actions and its Sound page sets the volumes.
+type SettingsMode struct {
+	base
+	ctx *Context
+	ui  *ui.UI
+
+	page      settingsPage
+	rebinding input.Action // Waiting for input to bind to this; -1 if not
+}
+
+type settingsPage int
+
+const (
+	pageMain settingsPage = iota
+	pageControls
+	pageSound
+)
+
+func NewSettingsMode(ctx *Context) *SettingsMode {
+	return &SettingsMode{ctx: ctx, ui: ctx.NewUI(), rebinding: -1}
+}
+
+// Enter starts the list on the saved profile.
+func (s *SettingsMode) Enter() {
+	s.page = pageMain
+	s.ui.SetFocus(s.ctx.Settings.ActiveIndex)
+}
+
+func (s *SettingsMode) Exit() {
+	s.stopRebinding()
+}
+
+func (s *SettingsMode) Update() error {
+	u := s.ui
+	u.Begin(s.ctx.UITheme())
+	switch s.page {
+	case pageMain:
+		s.updateMain()
+	case pageControls:
+		s.updateControls()
+	case pageSound:
+		s.updateSound()
+	}
+	u.End()
+	return nil
+}
+
+func (s *SettingsMode) updateMain() {
+	set := &s.ctx.Settings
+	names := make([]string, len(set.Profiles))
+	for i, p := range set.Profiles {
+		names[i] = p.Name
+	}
+
+	u := s.ui
+	u.Column(4, 2, 150)
+	u.LabelStyle("SETTINGS", s.ctx.AccentStyle())
+	u.Spacer(14)
+	u.Label("Color profile:")
+	prev := set.ActiveIndex
+	u.List(names, &set.ActiveIndex)
+	if set.ActiveIndex != prev {
+		s.ctx.ApplyProfile()
+		s.save()
+	}
+	u.Spacer(8)
+	if u.Button("Controls") {
+		s.open(pageControls)
+	}
+	if u.Button("Sound") {
+		s.open(pageSound)
+	}
+	if u.Button("Back") {
+		s.ctx.Scenes.Switch(ModeDirectory)
+	}
+}
+
+// --- Controls ---
+
+func (s *SettingsMode) updateControls() {
+	set := &s.ctx.Settings
+	if s.rebinding >= 0 {
+		s.capture()
+	}
+
+	u := s.ui
+	u.Column(4, 2, ScreenWidth-8)
+	u.LabelStyle("CONTROLS", s.ctx.AccentStyle())
+	u.Spacer(4)
+	for a := input.Action(0); a < input.NumActions; a++ {
+		label := fmt.Sprintf("%-8s %s", a.Label(), s.ctx.Actions.Binding(a))
+		if u.Button(label) {
+			s.rebinding = a
+			s.ctx.Actions.Capturing = true
+		}
+	}
+
+	u.Row(4, 190)
+	if u.Button("Reset to defaults") {
+		set.Bindings = input.DefaultBindings()
+		s.ctx.Actions.SetBindings(set.Bindings)
+		s.save()
+	}
+	if u.Button("Done") {
+		s.open(pageMain)
+	}
+
+	if s.rebinding >= 0 {
+		msg := fmt.Sprintf("Press a key or gamepad button for %s.\n(ESC or click cancels)", s.rebinding.Label())
+		u.Modal("REBIND", msg)
+	}
+}
+
+// capture binds the first key or pad button pressed to the action being
+// rebound. A key replaces the action's keys and a button its buttons, so
+// the other device keeps working.
+func (s *SettingsMode) capture() {
+	b, ok := s.ctx.Actions.Pushed()
+	switch {
+	case s.ctx.Pointer.JustPressed(), ok && len(b.Keys) > 0 && b.Keys[0] == ebiten.KeyEscape:
+		s.stopRebinding()
+	case ok:
+		set := &s.ctx.Settings
+		bind := set.Bindings[s.rebinding]
+		if len(b.Keys) > 0 {
+			bind.Keys = b.Keys
+		} else {
+			bind.Pad = b.Pad
+		}
+		set.Bindings[s.rebinding] = bind
+		s.ctx.Actions.SetBindings(set.Bindings)
+		s.save()
+		s.stopRebinding()
+	}
+}
+
+// --- Sound ---
+
+func (s *SettingsMode) updateSound() {
+	vol := s.ctx.Settings.Audio
+	u := s.ui
+	u.Column(4, 2, 200)
+	u.LabelStyle("SOUND", s.ctx.AccentStyle())
+	u.Spacer(14)
+	changed := false
+	changed = u.Slider("Master", &vol.Master, 0, 100, 10, "%d%%") || changed
+	changed = u.Slider("Music ", &vol.Music, 0, 100, 10, "%d%%") || changed
+	changed = u.Slider("SFX   ", &vol.SFX, 0, 100, 10, "%d%%") || changed
+	if changed {
+		s.ctx.Audio.SetVolumes(*vol)
+		s.ctx.Audio.Play(audio.SoundDot)
+	}
+	u.Spacer(8)
+	u.Label("Focus ambience:")
+	sel := slices.Index(audio.Ambients, vol.Ambient)
+	names := []string{"Off", "Rain", "Cafe", "Lo-fi"}
+	prev := sel
+	u.List(names, &sel)
+	if sel != prev && sel >= 0 {
+		vol.Ambient = audio.Ambients[sel]
+		changed = true
+	}
+	if changed {
+		s.save()
+	}
+	u.Spacer(8)
+	if u.Button("Done") {
+		s.open(pageMain)
+	}
+}
+
+// open switches page, focusing its first widget.
+func (s *SettingsMode) open(p settingsPage) {
+	s.page = p
+	s.ui.SetFocus(0)
+}
+
+func (s *SettingsMode) stopRebinding() {
+	s.rebinding = -1
+	s.ctx.Actions.Capturing = false
+}
+
+func (s *SettingsMode) save() {
+	if s.ctx.SaveSettings != nil {
+		s.ctx.SaveSettings()
+	}
+}
+
+func (s *SettingsMode) Draw(screen *ebiten.Image) {
+	s.ui.Draw(screen)
+	if s.page != pageMain {
+		return
+	}
+	vector.FillRect(screen, 180, 160, 120, 30, s.ctx.AccentColor, false)
+	pandaPlain.Draw(screen, 240, 200, nil)
+}
@@ -0,0 +1,109 @@
+package gamemode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"panda/internal/history"
+	"panda/internal/input"
+	"panda/internal/pixeltext"
+)
+
+// Chart area
+const (
+	chartX, chartY = 20, 36
+	chartW, chartH = 280, 110
+)
+
+// statsChart is the chart and its day labels; a click there switches
+// the view.
+var statsChart = image.Rect(chartX, chartY, chartX+chartW, chartY+chartH+pixeltext.LineHeight)
+
+var colChartAxis = color.RGBA{0x80, 0x80, 0x80, 0xff}
+
+// StatsMode charts focused minutes per day from the session history.
+// LEFT/RIGHT (or a click on the chart) switches between the last 7 and
+// 30 days.
+type StatsMode struct {
+	base
+	ctx *Context
+
+	Days    int
+	Summary history.Summary
+}
+
+func NewStatsMode(ctx *Context) *StatsMode {
+	return &StatsMode{ctx: ctx, Days: 7}
+}
+
+// Enter reloads the history so the chart includes the latest sessions.
+func (s *StatsMode) Enter() { s.refresh() }
+
+func (s *StatsMode) refresh() {
+	var sessions []history.Entry
+	if s.ctx.History != nil {
+		var err error
+		if sessions, err = s.ctx.History.AllSessions(); err != nil {
+			log.Printf("stats: %v", err)
+		}
+	}
+	s.Summary = history.Summarize(sessions, s.ctx.Clock.Now(), s.Days)
+}
+
+func (s *StatsMode) Update() error {
+	a, p := s.ctx.Actions, s.ctx.Pointer
+	if a.JustPressed(input.ActionLeft) || a.JustPressed(input.ActionRight) || (p.JustPressed() && p.In(statsChart)) {
+		if s.Days == 7 {
+			s.Days = 30
+		} else {
+			s.Days = 7
+		}
+		s.refresh()
+	}
+	return nil
+}
+
+func (s *StatsMode) Draw(screen *ebiten.Image) {
+	sum := s.Summary
+	pixeltext.Draw(screen, fmt.Sprintf("FOCUS STATS < %d DAYS >", s.Days), 4, 2, s.ctx.AccentStyle())
+
+	// Scale to the busiest day, but never below one full session
+	peak := 25 * time.Minute
+	for _, d := range sum.Days {
+		peak = max(peak, d.Focused)
+	}
+	pixeltext.Draw(screen, fmt.Sprintf("%dm", int(peak.Minutes())), chartX+chartW, chartY-16, s.ctx.TextStyle().WithAlign(pixeltext.Right))
+
+	n := len(sum.Days)
+	labelStyle := s.ctx.TextStyle().WithAlign(pixeltext.Center)
+	slot := float32(chartW) / float32(n)
+	gap := max(slot/5, 1)
+	for i, d := range sum.Days {
+		h := float32(chartH) * float32(d.Focused) / float32(peak)
+		x := chartX + float32(i)*slot
+		vector.FillRect(screen, x+gap/2, chartY+chartH-h, slot-gap, h, s.ctx.AccentColor, false)
+
+		// Label every day of the week view, every fifth day of the month
+		if n <= 7 {
+			pixeltext.Draw(screen, d.Day.Weekday().String()[:2], float64(x+slot/2), chartY+chartH+2, labelStyle)
+		} else if (n-1-i)%5 == 0 {
+			pixeltext.Draw(screen, fmt.Sprint(d.Day.Day()), float64(x+slot/2), chartY+chartH+2, labelStyle)
+		}
+	}
+	vector.StrokeLine(screen, chartX, chartY+chartH, chartX+chartW, chartY+chartH, 1, colChartAxis, false)
+
+	msg := fmt.Sprintf("Streak: %dd   Best: %dd\nAvg session: %dm   Done: %d\nTotal focus: %s",
+		sum.CurrentStreak, sum.LongestStreak, int(sum.AverageSession.Minutes()), sum.Completed, formatHours(sum.Total))
+	pixeltext.Draw(screen, msg, 20, 176, s.ctx.TextStyle())
+}
+
+// formatHours renders a duration as "5h 20m".
+func formatHours(d time.Duration) string {
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
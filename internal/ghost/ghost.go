@@ -0,0 +1,204 @@
+// Package ghost is the gophers' brains in Panda-Man. Each ghost picks a
+// target tile from its personality and the current mode, then takes the
+// first step of the shortest route towards it, the way the arcade ghosts
+// did but with real pathfinding, so walls never leave one stuck.
+package ghost
+
+import (
+	"math/rand"
+
+	"panda/internal/maze"
+)
+
+// Mode is what the ghosts are up to.
+type Mode int
+
+const (
+	Scatter    Mode = iota // Each heads for its own corner
+	Chase                  // Each hunts the panda its own way
+	Frightened             // Wander at random, slowly, and can be eaten
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Chase:
+		return "chase"
+	case Frightened:
+		return "frightened"
+	}
+	return "scatter"
+}
+
+// Personality decides where a ghost aims while chasing.
+type Personality int
+
+const (
+	Chaser   Personality = iota // Straight at the panda
+	Ambusher                    // Four tiles ahead of the panda, to cut it off
+	Flanker                     // Opposite the Chaser, pinning the panda between them
+	Shy                         // Chases from afar, retreats to its corner up close
+	NumPersonalities
+)
+
+// shyDistance is how close (in tiles) a Shy ghost dares to come.
+const shyDistance = 8
+
+// Target is what the ghosts know about the board when choosing a target.
+type Target struct {
+	Player    maze.Point
+	PlayerDir maze.Point // Last step the panda took
+	Chaser    maze.Point // Where the Chaser is, for the Flanker
+}
+
+type Ghost struct {
+	Personality
+	Pos, Dir   maze.Point
+	Home       maze.Point // Spawn, where it waits to be let out
+	Corner     maze.Point // Scatter target
+	Frightened bool
+	Eaten      bool // Just eyes, hurrying home to come back to life
+
+	Delay int // Ticks per tile; twice as many while frightened
+	Wait  int // Ticks before it leaves home
+
+	timer   int
+	reverse bool // Turn around on the next step
+}
+
+// New puts a ghost at home on l; its scatter corner follows from its
+// personality, like the arcade: top right, top left, bottom right,
+// bottom left.
+func New(p Personality, home maze.Point, l *maze.Level, delay int) *Ghost {
+	corners := [NumPersonalities]maze.Point{
+		Chaser:   {X: l.W - 1, Y: -1},
+		Ambusher: {X: 0, Y: -1},
+		Flanker:  {X: l.W - 1, Y: l.H},
+		Shy:      {X: 0, Y: l.H},
+	}
+	return &Ghost{Personality: p, Pos: home, Home: home, Corner: corners[p%NumPersonalities], Delay: delay}
+}
+
+// Reverse makes the ghost turn back on its next step, which is how the
+// ghosts give away a change of mode.
+func (g *Ghost) Reverse() { g.reverse = true }
+
+// Target is the tile g heads for in mode.
+func (g *Ghost) Target(mode Mode, t Target) maze.Point {
+	if mode == Scatter {
+		return g.Corner
+	}
+	switch g.Personality % NumPersonalities {
+	case Ambusher:
+		return t.Player.Add(scale(t.PlayerDir, 4))
+	case Flanker:
+		// Double the vector from the Chaser to two tiles ahead of the panda
+		pivot := t.Player.Add(scale(t.PlayerDir, 2))
+		return t.Chaser.Add(scale(pivot.Add(scale(t.Chaser, -1)), 2))
+	case Shy:
+		if manhattan(g.Pos, t.Player) < shyDistance {
+			return g.Corner
+		}
+	}
+	return t.Player
+}
+
+// Eat sends g's eyes home at speed.
+func (g *Ghost) Eat() {
+	g.Frightened, g.Eaten = false, true
+}
+
+// Harmful reports whether touching g costs the panda a life.
+func (g *Ghost) Harmful() bool { return !g.Frightened && !g.Eaten }
+
+// Update moves g along for a tick. Ghosts glide from tile to tile,
+// taking their delay over each, and choose the next way to go as they
+// arrive. mode is the pack's Scatter or Chase; a frightened ghost ignores
+// it and wanders.
+func (g *Ghost) Update(l *maze.Level, mode Mode, t Target, rng *rand.Rand) {
+	if g.Wait > 0 {
+		g.Wait--
+		return
+	}
+	if g.reverse && g.timer > 0 {
+		// Turn around on the spot, heading back to the tile just left
+		g.reverse = false
+		g.Pos, _ = l.Wrap(g.Pos.Add(g.Dir))
+		g.Dir = scale(g.Dir, -1)
+		g.timer = max(g.delay()-g.timer, 0)
+	}
+	if g.timer == 0 {
+		if g.Eaten && g.Pos == g.Home {
+			g.Eaten = false
+		}
+		g.Dir = g.choose(l, mode, t, rng)
+		if g.Dir == (maze.Point{}) {
+			return
+		}
+	}
+	if g.timer++; g.timer >= g.delay() {
+		g.timer = 0
+		g.Pos, _ = l.Wrap(g.Pos.Add(g.Dir))
+	}
+}
+
+// delay is how many ticks g takes per tile: eyes hurry home, frightened
+// ghosts dawdle.
+func (g *Ghost) delay() int {
+	switch {
+	case g.Eaten:
+		return max(g.Delay/3, 1)
+	case g.Frightened:
+		return g.Delay * 2
+	}
+	return g.Delay
+}
+
+// Offset is how far g is from Pos towards the next tile, 0-1.
+func (g *Ghost) Offset() float64 {
+	return min(float64(g.timer)/float64(g.delay()), 1)
+}
+
+// choose picks the direction to leave Pos in; the zero Point to stay.
+func (g *Ghost) choose(l *maze.Level, mode Mode, t Target, rng *rand.Rand) maze.Point {
+	back := g.Pos.Add(scale(g.Dir, -1))
+	if g.reverse {
+		g.reverse = false
+		if p, ok := l.Wrap(back); ok && l.At(p) != maze.Wall && g.Dir != (maze.Point{}) {
+			return scale(g.Dir, -1)
+		}
+	}
+	switch {
+	case g.Eaten:
+		return NextStep(l, g.Pos, back, g.Home)
+	case g.Frightened:
+		return wander(l, g.Pos, back, rng)
+	}
+	return NextStep(l, g.Pos, back, g.Target(mode, t))
+}
+
+// wander picks a random way on, not back unless it's a dead end.
+func wander(l *maze.Level, from, back maze.Point, rng *rand.Rand) maze.Point {
+	var ways []maze.Point
+	for _, d := range dirs {
+		if p, ok := l.Wrap(from.Add(d)); ok && l.At(p) != maze.Wall && from.Add(d) != back {
+			ways = append(ways, d)
+		}
+	}
+	if len(ways) == 0 {
+		return back.Add(scale(from, -1))
+	}
+	return ways[rng.Intn(len(ways))]
+}
+
+func scale(p maze.Point, k int) maze.Point { return maze.Point{X: p.X * k, Y: p.Y * k} }
+
+func manhattan(a, b maze.Point) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
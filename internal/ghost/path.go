@@ -0,0 +1,57 @@
+package ghost
+
+import "panda/internal/maze"
+
+// dirs in the arcade's tie-break order: up, left, down, right.
+var dirs = []maze.Point{{X: 0, Y: -1}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}
+
+// NextStep is the direction of the first step on the shortest route from
+// from to target, found by a breadth-first search through the open tiles
+// (tunnels included). Targets inside walls or off the board are fine: the
+// route leads to the reachable tile nearest to them. The search won't
+// start by stepping onto back, so ghosts don't dither, unless that is the
+// only way out. It returns the zero Point when there's nowhere to go.
+func NextStep(l *maze.Level, from, back, target maze.Point) maze.Point {
+	type node struct {
+		at    maze.Point
+		first maze.Point // Direction of the first step that led here
+	}
+	seen := map[maze.Point]bool{from: true}
+	var queue []node
+	for _, pass := range []bool{false, true} {
+		for _, d := range dirs {
+			p, ok := l.Wrap(from.Add(d))
+			if !ok || l.At(p) == maze.Wall || seen[p] || (from.Add(d) == back) != pass {
+				continue
+			}
+			seen[p] = true
+			queue = append(queue, node{p, d})
+		}
+		if len(queue) > 0 {
+			break
+		}
+	}
+	if len(queue) == 0 {
+		return maze.Point{}
+	}
+
+	// Breadth-first, so among tiles equally near the target the first
+	// one found is the closest to us
+	best, bestDist := queue[0], manhattan(queue[0].at, target)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if d := manhattan(n.at, target); d < bestDist {
+			best, bestDist = n, d
+		}
+		for _, d := range dirs {
+			p, ok := l.Wrap(n.at.Add(d))
+			if !ok || l.At(p) == maze.Wall || seen[p] {
+				continue
+			}
+			seen[p] = true
+			queue = append(queue, node{p, n.first})
+		}
+	}
+	return best.first
+}
@@ -0,0 +1,66 @@
+package ghost
+
+import (
+	"strings"
+	"testing"
+
+	"panda/internal/maze"
+)
+
+func parse(t *testing.T, rows ...string) *maze.Level {
+	t.Helper()
+	l, err := maze.Parse("test.txt", strings.NewReader(strings.Join(rows, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+var (
+	up    = maze.Point{X: 0, Y: -1}
+	left  = maze.Point{X: -1, Y: 0}
+	right = maze.Point{X: 1, Y: 0}
+	none  = maze.Point{X: -9, Y: -9} // A back tile that's never a neighbour
+)
+
+func TestNextStep(t *testing.T) {
+	walled := parse(t,
+		"#######",
+		"#.....#",
+		"#.###.#",
+		"#P#G..#",
+		"#######",
+	)
+	tunnel := parse(t,
+		"#####",
+		"#P.G#",
+		" ... ",
+		"#.o.#",
+		"#####",
+	)
+	boxed := &maze.Level{W: 3, H: 3, Tiles: []maze.Tile{
+		maze.Wall, maze.Wall, maze.Wall,
+		maze.Wall, maze.Floor, maze.Wall,
+		maze.Wall, maze.Wall, maze.Wall,
+	}}
+	tests := []struct {
+		name               string
+		l                  *maze.Level
+		from, back, target maze.Point
+		want               maze.Point
+	}{
+		{"around a wall", walled, maze.Point{X: 1, Y: 3}, none, maze.Point{X: 3, Y: 3}, up},
+		{"no turning back", walled, maze.Point{X: 3, Y: 1}, maze.Point{X: 2, Y: 1}, maze.Point{X: 1, Y: 1}, right},
+		{"back out of a dead end", walled, maze.Point{X: 3, Y: 3}, maze.Point{X: 4, Y: 3}, maze.Point{X: 1, Y: 3}, right},
+		{"target in a wall", walled, maze.Point{X: 1, Y: 1}, none, maze.Point{X: 3, Y: 2}, right},
+		{"through the tunnel", tunnel, maze.Point{X: 0, Y: 2}, none, maze.Point{X: 4, Y: 2}, left},
+		{"nowhere to go", boxed, maze.Point{X: 1, Y: 1}, none, maze.Point{X: 0, Y: 0}, maze.Point{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextStep(tt.l, tt.from, tt.back, tt.target); got != tt.want {
+				t.Errorf("NextStep from %v to %v = %v, want %v", tt.from, tt.target, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package ghost
+
+// Phase is one stretch of the mode schedule.
+type Phase struct {
+	Mode  Mode
+	Ticks int // 0 = for the rest of the level
+}
+
+// ArcadeSchedule is the arcade's first-level rhythm at 60 ticks a second:
+// short scatters between long chases, ending in a chase for good. Later
+// levels pass a smaller scatter so the breathers shrink.
+func ArcadeSchedule(scatter int) []Phase {
+	const s = 60
+	return []Phase{
+		{Scatter, scatter * s}, {Chase, 20 * s},
+		{Scatter, scatter * s}, {Chase, 20 * s},
+		{Scatter, scatter * s * 5 / 7}, {Chase, 20 * s},
+		{Scatter, scatter * s * 5 / 7}, {Chase, 0},
+	}
+}
+
+// Timer runs the scatter/chase schedule shared by the whole pack, and
+// the frightened spell on top of it (which pauses the schedule).
+type Timer struct {
+	Schedule []Phase
+
+	phase, tick int
+	frightened  int // Ticks of fright left
+}
+
+func NewTimer(schedule []Phase) *Timer {
+	return &Timer{Schedule: schedule}
+}
+
+// Mode is Scatter or Chase, whatever the fright.
+func (t *Timer) Mode() Mode {
+	if t.phase >= len(t.Schedule) {
+		return Chase
+	}
+	return t.Schedule[t.phase].Mode
+}
+
+// Frightened returns how many ticks of fright are left, 0 when none.
+func (t *Timer) Frightened() int { return t.frightened }
+
+// Frighten starts (or restarts) a fright lasting ticks.
+func (t *Timer) Frighten(ticks int) { t.frightened = ticks }
+
+// Update advances a tick. It reports whether the fright just ended, and
+// whether the schedule switched between scatter and chase, when the
+// ghosts should turn around.
+func (t *Timer) Update() (calmed, switched bool) {
+	if t.frightened > 0 {
+		t.frightened--
+		return t.frightened == 0, false
+	}
+	if t.phase >= len(t.Schedule) || t.Schedule[t.phase].Ticks == 0 {
+		return false, false
+	}
+	if t.tick++; t.tick >= t.Schedule[t.phase].Ticks {
+		t.tick = 0
+		t.phase++
+		return false, true
+	}
+	return false, false
+}
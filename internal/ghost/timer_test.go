@@ -0,0 +1,42 @@
+package ghost
+
+import "testing"
+
+func TestTimer(t *testing.T) {
+	tm := NewTimer([]Phase{{Scatter, 3}, {Chase, 2}, {Scatter, 2}, {Chase, 0}})
+	tests := []struct {
+		name             string
+		frighten         int // Before the ticks
+		ticks            int
+		mode             Mode
+		calmed, switched bool // On the last tick
+	}{
+		{"first scatter", 0, 2, Scatter, false, false},
+		{"into chase", 0, 1, Chase, false, true},
+		{"fright pauses the schedule", 4, 3, Chase, false, false},
+		{"fright ends", 0, 1, Chase, true, false},
+		{"schedule resumes", 0, 2, Scatter, false, true},
+		{"chase for good", 0, 2, Chase, false, true},
+		{"and stays", 0, 1000, Chase, false, false},
+	}
+	for _, tt := range tests {
+		if tt.frighten > 0 {
+			tm.Frighten(tt.frighten)
+		}
+		var calmed, switched bool
+		for range tt.ticks {
+			calmed, switched = tm.Update()
+		}
+		if tm.Mode() != tt.mode || calmed != tt.calmed || switched != tt.switched {
+			t.Errorf("%s: %v (calmed %v, switched %v), want %v (%v, %v)",
+				tt.name, tm.Mode(), calmed, switched, tt.mode, tt.calmed, tt.switched)
+		}
+	}
+}
+
+func TestArcadeSchedule(t *testing.T) {
+	s := ArcadeSchedule(7)
+	if s[0] != (Phase{Scatter, 7 * 60}) || s[len(s)-1] != (Phase{Chase, 0}) {
+		t.Errorf("got %v, want a 7s scatter first and a chase for good last", s)
+	}
+}
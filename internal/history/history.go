@@ -0,0 +1,138 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+)
+
+type Outcome string
+
+const (
+	OutcomeStarted   Outcome = "started"
+	OutcomePaused    Outcome = "paused"
+	OutcomeCompleted Outcome = "completed"
+	OutcomeAbandoned Outcome = "abandoned"
+)
+
+// Final reports whether no more records will follow for the session.
+func (o Outcome) Final() bool {
+	return o == OutcomeCompleted || o == OutcomeAbandoned
+}
+
+// Entry is one line of the history file. A session writes a "started"
+// line, one "paused" line per interruption and finally "completed" or
+// "abandoned"; all of them share the session's Start time.
+type Entry struct {
+	Start         time.Time `json:"start"`
+	At            time.Time `json:"at"` // When this line was written
+	PlannedSec    int64     `json:"planned_sec"`
+	ActualSec     int64     `json:"actual_sec"` // Focused time so far, pauses excluded
+	Outcome       Outcome   `json:"outcome"`
+	Interruptions int       `json:"interruptions"`
+}
+
+func (e Entry) Planned() time.Duration { return time.Duration(e.PlannedSec) * time.Second }
+func (e Entry) Actual() time.Duration  { return time.Duration(e.ActualSec) * time.Second }
+
+// Log is an append-only JSON Lines file of focus sessions.
+type Log struct {
+	Path string
+}
+
+func Open(path string) *Log {
+	return &Log{Path: path}
+}
+
+func (l *Log) Append(e Entry) error {
+	d, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(d, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Records returns every line in file order. A missing file is an empty
+// history; lines that don't parse (e.g. cut short by a crash) are skipped.
+func (l *Log) Records() ([]Entry, error) {
+	f, err := os.Open(l.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []Entry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e Entry
+		if json.Unmarshal(sc.Bytes(), &e) == nil && !e.Start.IsZero() {
+			out = append(out, e)
+		}
+	}
+	return out, sc.Err()
+}
+
+// Sessions returns one entry per session that started in [from, to): the
+// latest line written for it, ordered by start time.
+func (l *Log) Sessions(from, to time.Time) ([]Entry, error) {
+	recs, err := l.Records()
+	if err != nil {
+		return nil, err
+	}
+	latest := map[int64]Entry{}
+	for _, e := range recs {
+		if e.Start.Before(from) || !e.Start.Before(to) {
+			continue
+		}
+		latest[e.Start.UnixNano()] = e
+	}
+	out := make([]Entry, 0, len(latest))
+	for _, e := range latest {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out, nil
+}
+
+// AllSessions is Sessions over the whole file.
+func (l *Log) AllSessions() ([]Entry, error) {
+	return l.Sessions(time.Time{}, time.Unix(1<<62, 0))
+}
+
+// Day returns the sessions started on t's calendar day (in t's location).
+func (l *Log) Day(t time.Time) ([]Entry, error) {
+	from := StartOfDay(t)
+	return l.Sessions(from, from.AddDate(0, 0, 1))
+}
+
+// Week returns the sessions started in the Monday-to-Sunday week holding t.
+func (l *Log) Week(t time.Time) ([]Entry, error) {
+	from := StartOfWeek(t)
+	return l.Sessions(from, from.AddDate(0, 0, 7))
+}
+
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func StartOfWeek(t time.Time) time.Time {
+	day := StartOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return day.AddDate(0, 0, -offset)
+}
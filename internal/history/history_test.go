@@ -0,0 +1,128 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"panda/internal/pomodoro"
+)
+
+var t0 = time.Date(2025, time.January, 8, 9, 0, 0, 0, time.UTC) // A Wednesday
+
+func TestRecordsSkipBrokenLines(t *testing.T) {
+	l := Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	if recs, err := l.Records(); recs != nil || err != nil {
+		t.Fatalf("missing file: got %v, %v; want an empty history", recs, err)
+	}
+	if err := l.Append(Entry{Start: t0, At: t0, Outcome: OutcomeStarted}); err != nil {
+		t.Fatal(err)
+	}
+	// A crash mid-write leaves half a line
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"start":"2025-01-08T09:30:00Z","at":` + "\n")
+	f.Close()
+	if err := l.Append(Entry{Start: t0, At: t0.Add(time.Minute), Outcome: OutcomeCompleted}); err != nil {
+		t.Fatal(err)
+	}
+
+	recs, err := l.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[0].Outcome != OutcomeStarted || recs[1].Outcome != OutcomeCompleted {
+		t.Errorf("got %+v, want the started and completed lines", recs)
+	}
+}
+
+func TestSessionsKeepLatestLine(t *testing.T) {
+	l := Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	monday := StartOfWeek(t0)
+	lines := []Entry{
+		{Start: monday.AddDate(0, 0, -1), Outcome: OutcomeCompleted}, // Last week's Sunday
+		{Start: monday, Outcome: OutcomeStarted},
+		{Start: monday, Outcome: OutcomePaused, Interruptions: 1},
+		{Start: monday, Outcome: OutcomeCompleted, Interruptions: 1},
+		{Start: t0, Outcome: OutcomeStarted},
+		{Start: t0, Outcome: OutcomeAbandoned},
+		{Start: monday.AddDate(0, 0, 7), Outcome: OutcomeStarted}, // Next Monday
+	}
+	for _, e := range lines {
+		e.At = e.Start
+		if err := l.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		get  func() ([]Entry, error)
+		want []Outcome
+	}{
+		{"week", func() ([]Entry, error) { return l.Week(t0) }, []Outcome{OutcomeCompleted, OutcomeAbandoned}},
+		{"day", func() ([]Entry, error) { return l.Day(t0) }, []Outcome{OutcomeAbandoned}},
+		{"all", l.AllSessions, []Outcome{OutcomeCompleted, OutcomeCompleted, OutcomeAbandoned, OutcomeStarted}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var outcomes []Outcome
+			for _, e := range got {
+				outcomes = append(outcomes, e.Outcome)
+			}
+			if !slices.Equal(outcomes, tt.want) {
+				t.Errorf("got %v, want %v", outcomes, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	for _, day := range []int{6, 8, 12} { // Monday, Wednesday, Sunday
+		at := time.Date(2025, time.January, day, 23, 59, 0, 0, time.UTC)
+		if got, want := StartOfWeek(at), time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("StartOfWeek(%v) = %v, want %v", at, got, want)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	l := Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	e := pomodoro.New(pomodoro.Config{WorkMinutes: 25})
+	e.Subscribe(NewRecorder(l).Handle)
+
+	e.Start(t0)
+	e.Pause(t0.Add(10 * time.Minute))
+	e.Resume(t0.Add(20 * time.Minute))
+	e.Update(t0.Add(35 * time.Minute)) // Work done; the break isn't recorded
+	e.Start(t0.Add(36 * time.Minute))
+	e.Update(t0.Add(41 * time.Minute))
+	e.Start(t0.Add(50 * time.Minute))
+	e.Reset(t0.Add(55 * time.Minute))
+
+	sessions, err := l.AllSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{Start: t0, PlannedSec: 25 * 60, ActualSec: 25 * 60, Outcome: OutcomeCompleted, Interruptions: 1},
+		{Start: t0.Add(50 * time.Minute), PlannedSec: 25 * 60, ActualSec: 5 * 60, Outcome: OutcomeAbandoned},
+	}
+	if len(sessions) != len(want) {
+		t.Fatalf("got %+v, want %+v", sessions, want)
+	}
+	for i, s := range sessions {
+		if !s.Start.Equal(want[i].Start) || s.PlannedSec != want[i].PlannedSec || s.ActualSec != want[i].ActualSec ||
+			s.Outcome != want[i].Outcome || s.Interruptions != want[i].Interruptions {
+			t.Errorf("session %d = %+v, want %+v", i, s, want[i])
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package history
+
+import (
+	"log"
+	"time"
+
+	"panda/internal/pomodoro"
+)
+
+// Recorder turns Pomodoro timer events into history lines. Only work
+// phases are recorded; breaks aren't focus sessions.
+type Recorder struct {
+	log *Log
+
+	active        bool
+	start         time.Time
+	interruptions int
+}
+
+func NewRecorder(l *Log) *Recorder {
+	return &Recorder{log: l}
+}
+
+// Handle is a pomodoro.Engine listener.
+func (r *Recorder) Handle(ev pomodoro.Event) {
+	if ev.Phase != pomodoro.PhaseWork {
+		return
+	}
+	switch ev.Kind {
+	case pomodoro.EventStarted:
+		r.active = true
+		r.start = ev.At
+		r.interruptions = 0
+		r.write(ev, OutcomeStarted)
+	case pomodoro.EventPaused:
+		if r.active {
+			r.interruptions++
+			r.write(ev, OutcomePaused)
+		}
+	case pomodoro.EventCompleted:
+		if r.active {
+			r.write(ev, OutcomeCompleted)
+			r.active = false
+		}
+	case pomodoro.EventSkipped, pomodoro.EventReset:
+		if r.active {
+			r.write(ev, OutcomeAbandoned)
+			r.active = false
+		}
+	}
+}
+
+func (r *Recorder) write(ev pomodoro.Event, outcome Outcome) {
+	err := r.log.Append(Entry{
+		Start:         r.start,
+		At:            ev.At,
+		PlannedSec:    int64(ev.Length / time.Second),
+		ActualSec:     int64(ev.Elapsed / time.Second),
+		Outcome:       outcome,
+		Interruptions: r.interruptions,
+	})
+	if err != nil {
+		log.Printf("history: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package history
+
+import "time"
+
+// DayTotal is the focused time logged on one calendar day.
+type DayTotal struct {
+	Day       time.Time
+	Focused   time.Duration
+	Completed int
+}
+
+// Summary aggregates sessions for the stats screen.
+type Summary struct {
+	Days           []DayTotal // Oldest first, ending today
+	CurrentStreak  int        // Consecutive days with a completed session
+	LongestStreak  int
+	AverageSession time.Duration // Mean length of completed sessions
+	Completed      int
+	Total          time.Duration // Focused time across all sessions
+}
+
+// Summarize builds a Summary from sessions (as returned by Log.Sessions)
+// with a per-day chart of the last n days up to and including today.
+// Streaks and averages cover every session given, not just the chart.
+func Summarize(sessions []Entry, today time.Time, n int) Summary {
+	today = StartOfDay(today)
+	var s Summary
+
+	focused := map[time.Time]time.Duration{}
+	completed := map[time.Time]int{}
+	var completedTime time.Duration
+	for _, e := range sessions {
+		day := StartOfDay(e.Start.In(today.Location()))
+		focused[day] += e.Actual()
+		s.Total += e.Actual()
+		if e.Outcome == OutcomeCompleted {
+			completed[day]++
+			s.Completed++
+			completedTime += e.Actual()
+		}
+	}
+	if s.Completed > 0 {
+		s.AverageSession = completedTime / time.Duration(s.Completed)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		day := today.AddDate(0, 0, -i)
+		s.Days = append(s.Days, DayTotal{Day: day, Focused: focused[day], Completed: completed[day]})
+	}
+
+	// Current streak: count back from today, or from yesterday if today
+	// has nothing yet (the streak isn't broken until the day is over).
+	day := today
+	if completed[day] == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	for completed[day] > 0 {
+		s.CurrentStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	for day := range completed {
+		if completed[day.AddDate(0, 0, -1)] > 0 {
+			continue // Not the first day of a run
+		}
+		run := 0
+		for d := day; completed[d] > 0; d = d.AddDate(0, 0, 1) {
+			run++
+		}
+		if run > s.LongestStreak {
+			s.LongestStreak = run
+		}
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeStreaks(t *testing.T) {
+	today := time.Date(2025, time.March, 10, 18, 0, 0, 0, time.UTC)
+	// on builds a session days ago, completed or not
+	on := func(daysAgo int, done bool) Entry {
+		e := Entry{Start: today.AddDate(0, 0, -daysAgo), ActualSec: 25 * 60, Outcome: OutcomeCompleted}
+		if !done {
+			e.Outcome, e.ActualSec = OutcomeAbandoned, 5*60
+		}
+		return e
+	}
+	tests := []struct {
+		name             string
+		sessions         []Entry
+		current, longest int
+	}{
+		{"none", nil, 0, 0},
+		{"today only", []Entry{on(0, true)}, 1, 1},
+		{"today not done yet keeps yesterday's streak", []Entry{on(1, true), on(2, true)}, 2, 2},
+		{"broken yesterday", []Entry{on(0, true), on(2, true), on(3, true), on(4, true)}, 1, 3},
+		{"abandoned sessions don't count", []Entry{on(0, false), on(1, true)}, 1, 1},
+		{"two on a day count once", []Entry{on(0, true), on(0, true), on(1, true)}, 2, 2},
+		{"longest in the past", []Entry{on(9, true), on(8, true), on(7, true), on(6, true), on(1, true)}, 1, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Summarize(tt.sessions, today, 7)
+			if s.CurrentStreak != tt.current || s.LongestStreak != tt.longest {
+				t.Errorf("streaks %d/%d, want %d/%d", s.CurrentStreak, s.LongestStreak, tt.current, tt.longest)
+			}
+		})
+	}
+}
+
+func TestSummarizeDays(t *testing.T) {
+	today := time.Date(2025, time.March, 10, 18, 0, 0, 0, time.UTC)
+	sessions := []Entry{
+		{Start: today.AddDate(0, 0, -7), ActualSec: 60, Outcome: OutcomeCompleted}, // Before the chart
+		{Start: today.AddDate(0, 0, -2), ActualSec: 25 * 60, Outcome: OutcomeCompleted},
+		{Start: today.AddDate(0, 0, -2), ActualSec: 10 * 60, Outcome: OutcomeAbandoned},
+		{Start: today, ActualSec: 15 * 60, Outcome: OutcomeCompleted},
+	}
+	s := Summarize(sessions, today, 3)
+	if len(s.Days) != 3 || !s.Days[2].Day.Equal(StartOfDay(today)) {
+		t.Fatalf("days %+v, want three ending today", s.Days)
+	}
+	if d := s.Days[0]; d.Focused != 35*time.Minute || d.Completed != 1 {
+		t.Errorf("two days ago: %v focused, %d completed; want 35m, 1", d.Focused, d.Completed)
+	}
+	if s.Completed != 3 || s.Total != 51*time.Minute || s.AverageSession != (41*time.Minute)/3 {
+		t.Errorf("got %d completed, %v total, %v average", s.Completed, s.Total, s.AverageSession)
+	}
+}
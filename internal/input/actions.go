@@ -0,0 +1,222 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is something the player does, independent of the key or button
+// bound to it.
+type Action int
+
+const (
+	ActionConfirm Action = iota
+	ActionBack
+	ActionUp
+	ActionDown
+	ActionLeft
+	ActionRight
+	ActionReel  // Fishing: reel in
+	ActionCast1 // Fishing: cast onto the left, middle or right spot
+	ActionCast2
+	ActionCast3
+
+	NumActions = iota
+)
+
+var actionNames = [NumActions]string{"confirm", "back", "up", "down", "left", "right", "reel", "cast1", "cast2", "cast3"}
+var actionLabels = [NumActions]string{"Confirm", "Back", "Up", "Down", "Left", "Right", "Reel", "Cast 1", "Cast 2", "Cast 3"}
+
+// String is the action's saved name, e.g. "cast1".
+func (a Action) String() string {
+	if a >= 0 && a < NumActions {
+		return actionNames[a]
+	}
+	return fmt.Sprintf("action(%d)", int(a))
+}
+
+// Label is the action's display name, e.g. "Cast 1".
+func (a Action) Label() string {
+	if a >= 0 && a < NumActions {
+		return actionLabels[a]
+	}
+	return a.String()
+}
+
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText maps names it doesn't know (from a newer version) to -1
+// rather than failing, so the rest of the settings still load.
+func (a *Action) UnmarshalText(text []byte) error {
+	*a = -1
+	for i, name := range actionNames {
+		if name == string(text) {
+			*a = Action(i)
+		}
+	}
+	return nil
+}
+
+// Binding is every key and gamepad button that triggers an action.
+type Binding struct {
+	Keys []ebiten.Key `json:"keys,omitempty"`
+	Pad  []PadButton  `json:"pad,omitempty"`
+}
+
+// String lists the first key and pad button, e.g. "Enter / A".
+func (b Binding) String() string {
+	var parts []string
+	if len(b.Keys) > 0 {
+		parts = append(parts, b.Keys[0].String())
+	}
+	if len(b.Pad) > 0 {
+		parts = append(parts, b.Pad[0].String())
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " / ")
+}
+
+// Bindings maps actions to their inputs. It is saved with the settings,
+// keyed by action name.
+type Bindings map[Action]Binding
+
+// DefaultBindings is the keyboard layout the app always had plus a
+// standard gamepad: A confirms and reels, B backs out, the d-pad (or
+// left stick) steers and X/Y/RB cast onto the three spots.
+func DefaultBindings() Bindings {
+	pad := func(b ...ebiten.StandardGamepadButton) []PadButton {
+		out := make([]PadButton, len(b))
+		for i := range b {
+			out[i] = PadButton(b[i])
+		}
+		return out
+	}
+	return Bindings{
+		ActionConfirm: {Keys: []ebiten.Key{ebiten.KeyEnter, ebiten.KeyNumpadEnter}, Pad: pad(ebiten.StandardGamepadButtonRightBottom, ebiten.StandardGamepadButtonCenterRight)},
+		ActionBack:    {Keys: []ebiten.Key{ebiten.KeyEscape}, Pad: pad(ebiten.StandardGamepadButtonRightRight, ebiten.StandardGamepadButtonCenterLeft)},
+		ActionUp:      {Keys: []ebiten.Key{ebiten.KeyArrowUp}, Pad: pad(ebiten.StandardGamepadButtonLeftTop)},
+		ActionDown:    {Keys: []ebiten.Key{ebiten.KeyArrowDown}, Pad: pad(ebiten.StandardGamepadButtonLeftBottom)},
+		ActionLeft:    {Keys: []ebiten.Key{ebiten.KeyArrowLeft}, Pad: pad(ebiten.StandardGamepadButtonLeftLeft)},
+		ActionRight:   {Keys: []ebiten.Key{ebiten.KeyArrowRight}, Pad: pad(ebiten.StandardGamepadButtonLeftRight)},
+		ActionReel:    {Keys: []ebiten.Key{ebiten.KeySpace}, Pad: pad(ebiten.StandardGamepadButtonRightBottom, ebiten.StandardGamepadButtonFrontBottomRight)},
+		ActionCast1:   {Keys: []ebiten.Key{ebiten.KeyA}, Pad: pad(ebiten.StandardGamepadButtonRightLeft)},
+		ActionCast2:   {Keys: []ebiten.Key{ebiten.KeyS}, Pad: pad(ebiten.StandardGamepadButtonRightTop)},
+		ActionCast3:   {Keys: []ebiten.Key{ebiten.KeyD}, Pad: pad(ebiten.StandardGamepadButtonFrontTopRight)},
+	}
+}
+
+// WithDefaults returns a copy of b with every unbound action given its
+// default binding, so settings saved before an action existed still work.
+func (b Bindings) WithDefaults() Bindings {
+	out := DefaultBindings()
+	for a, bind := range b {
+		if a >= 0 && a < NumActions && (len(bind.Keys) > 0 || len(bind.Pad) > 0) {
+			out[a] = bind
+		}
+	}
+	return out
+}
+
+// Actions reads the keyboard and gamepad through the bindings. An action
+// is down while any of its inputs is, and has the same press semantics
+// as a single key.
+type Actions struct {
+	Keys *Keyboard
+	Pad  *Gamepad
+
+	bindings Bindings
+	tick     int
+	actions  [NumActions]press
+
+	// Capturing is set while a rebinding prompt waits for any input, so
+	// global shortcuts like Back keep out of the way.
+	Capturing bool
+}
+
+func NewActions(keys *Keyboard, pad *Gamepad) *Actions {
+	return &Actions{Keys: keys, Pad: pad, bindings: DefaultBindings()}
+}
+
+// SetBindings replaces the bindings, filling in defaults for gaps.
+func (a *Actions) SetBindings(b Bindings) {
+	a.bindings = b.WithDefaults()
+}
+
+// Binding returns what triggers act.
+func (a *Actions) Binding(act Action) Binding {
+	return a.bindings[act]
+}
+
+// Update derives the actions from the keyboard and gamepad. Call it once
+// per tick after updating both.
+func (a *Actions) Update() {
+	a.tick++
+	for act := range a.actions {
+		a.actions[act].update(a.tick, a.down(Action(act)))
+	}
+}
+
+func (a *Actions) down(act Action) bool {
+	b := a.bindings[act]
+	for _, k := range b.Keys {
+		if a.Keys.Pressed(k) {
+			return true
+		}
+	}
+	for _, p := range b.Pad {
+		if a.Pad.Pressed(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pressed reports whether any input bound to act is down.
+func (a *Actions) Pressed(act Action) bool {
+	return a.action(act).held > 0
+}
+
+// JustPressed reports whether act started this tick: one of its inputs
+// went down and none was already held.
+func (a *Actions) JustPressed(act Action) bool {
+	return a.action(act).justPressed()
+}
+
+// HeldTicks returns how long act has been down, 0 if it is up.
+func (a *Actions) HeldTicks(act Action) int {
+	return a.action(act).held
+}
+
+// HeldFor, LongPressed, DoubleTapped and Repeated work like Keyboard's.
+func (a *Actions) HeldFor(act Action, d time.Duration) bool {
+	return a.action(act).held >= max(Ticks(d), 1)
+}
+func (a *Actions) LongPressed(act Action) bool  { return a.action(act).longPressed() }
+func (a *Actions) DoubleTapped(act Action) bool { return a.action(act).doubleTapped() }
+func (a *Actions) Repeated(act Action) bool     { return a.action(act).repeated() }
+
+func (a *Actions) action(act Action) press {
+	if act < 0 || act >= NumActions {
+		return press{}
+	}
+	return a.actions[act]
+}
+
+// Pushed returns the key or pad button that went down this tick as a
+// one-input Binding, for rebinding prompts.
+func (a *Actions) Pushed() (Binding, bool) {
+	if k, ok := a.Keys.Pushed(); ok {
+		return Binding{Keys: []ebiten.Key{k}}, true
+	}
+	if p, ok := a.Pad.Pushed(); ok {
+		return Binding{Pad: []PadButton{p}}, true
+	}
+	return Binding{}, false
+}
@@ -0,0 +1,134 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// PadButton is a button in the standard gamepad layout. It is saved by
+// its Xbox-style name ("A", "LB", "DpadUp").
+type PadButton ebiten.StandardGamepadButton
+
+var padButtonNames = [...]string{
+	ebiten.StandardGamepadButtonRightBottom:      "A",
+	ebiten.StandardGamepadButtonRightRight:       "B",
+	ebiten.StandardGamepadButtonRightLeft:        "X",
+	ebiten.StandardGamepadButtonRightTop:         "Y",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "LB",
+	ebiten.StandardGamepadButtonFrontTopRight:    "RB",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "LT",
+	ebiten.StandardGamepadButtonFrontBottomRight: "RT",
+	ebiten.StandardGamepadButtonCenterLeft:       "Select",
+	ebiten.StandardGamepadButtonCenterRight:      "Start",
+	ebiten.StandardGamepadButtonLeftStick:        "LS",
+	ebiten.StandardGamepadButtonRightStick:       "RS",
+	ebiten.StandardGamepadButtonLeftTop:          "DpadUp",
+	ebiten.StandardGamepadButtonLeftBottom:       "DpadDown",
+	ebiten.StandardGamepadButtonLeftLeft:         "DpadLeft",
+	ebiten.StandardGamepadButtonLeftRight:        "DpadRight",
+	ebiten.StandardGamepadButtonCenterCenter:     "Home",
+}
+
+func (b PadButton) String() string {
+	if b >= 0 && int(b) < len(padButtonNames) {
+		return padButtonNames[b]
+	}
+	return fmt.Sprintf("Pad%d", int(b))
+}
+
+func (b PadButton) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText maps unknown names to -1, a button that is never
+// pressed, like Action does.
+func (b *PadButton) UnmarshalText(text []byte) error {
+	*b = -1
+	for i, name := range padButtonNames {
+		if name == string(text) {
+			*b = PadButton(i)
+		}
+	}
+	return nil
+}
+
+// stickDeadZone is how far the left stick has to lean to count as a
+// d-pad press.
+const stickDeadZone = 0.5
+
+// Gamepad polls every connected standard-layout gamepad and merges them
+// into one, so whichever pad is picked up just works. The left stick
+// doubles as the d-pad.
+type Gamepad struct {
+	src     Source
+	tick    int
+	ids     []ebiten.GamepadID
+	buttons [ebiten.StandardGamepadButtonMax + 1]press
+}
+
+func NewGamepad(src Source) *Gamepad {
+	return &Gamepad{src: src}
+}
+
+// Update samples the source. Call it exactly once at the start of a tick.
+func (g *Gamepad) Update() {
+	var down [ebiten.StandardGamepadButtonMax + 1]bool
+	g.ids = g.src.AppendGamepadIDs(g.ids[:0])
+	for _, id := range g.ids {
+		if !g.src.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for b := range down {
+			down[b] = down[b] || g.src.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButton(b))
+		}
+		x := g.src.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		y := g.src.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		down[ebiten.StandardGamepadButtonLeftLeft] = down[ebiten.StandardGamepadButtonLeftLeft] || x < -stickDeadZone
+		down[ebiten.StandardGamepadButtonLeftRight] = down[ebiten.StandardGamepadButtonLeftRight] || x > stickDeadZone
+		down[ebiten.StandardGamepadButtonLeftTop] = down[ebiten.StandardGamepadButtonLeftTop] || y < -stickDeadZone
+		down[ebiten.StandardGamepadButtonLeftBottom] = down[ebiten.StandardGamepadButtonLeftBottom] || y > stickDeadZone
+	}
+	g.tick++
+	for b, d := range down {
+		g.buttons[b].update(g.tick, d)
+	}
+}
+
+// Pressed reports whether b is down on any pad this tick.
+func (g *Gamepad) Pressed(b PadButton) bool {
+	return g.button(b).held > 0
+}
+
+// JustPressed reports whether b went down this tick.
+func (g *Gamepad) JustPressed(b PadButton) bool {
+	return g.button(b).justPressed()
+}
+
+// HeldTicks returns how many ticks b has been down, 0 if it is up.
+func (g *Gamepad) HeldTicks(b PadButton) int {
+	return g.button(b).held
+}
+
+// LongPressed, DoubleTapped and Repeated work like Keyboard's.
+func (g *Gamepad) LongPressed(b PadButton) bool  { return g.button(b).longPressed() }
+func (g *Gamepad) DoubleTapped(b PadButton) bool { return g.button(b).doubleTapped() }
+func (g *Gamepad) Repeated(b PadButton) bool     { return g.button(b).repeated() }
+
+// Pushed returns a button that went down this tick, like
+// Keyboard.Pushed.
+func (g *Gamepad) Pushed() (PadButton, bool) {
+	for b, p := range g.buttons {
+		if p.justPressed() {
+			return PadButton(b), true
+		}
+	}
+	return 0, false
+}
+
+func (g *Gamepad) button(b PadButton) press {
+	if b < 0 || int(b) >= len(g.buttons) {
+		return press{}
+	}
+	return g.buttons[b]
+}
@@ -0,0 +1,141 @@
+package input
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Source reports the raw input state right now: held keys, characters
+// typed since the last tick, the mouse, touches and gamepads. Ebiten is
+// the real hardware; Script is driven by hand for headless runs.
+type Source interface {
+	IsKeyPressed(key ebiten.Key) bool
+	AppendInputChars(runes []rune) []rune
+
+	// CursorPosition and TouchPosition are in logical screen coordinates
+	// (320x240), not window pixels.
+	CursorPosition() (x, y int)
+	IsMouseButtonPressed(button ebiten.MouseButton) bool
+	AppendTouchIDs(ids []ebiten.TouchID) []ebiten.TouchID
+	TouchPosition(id ebiten.TouchID) (x, y int)
+
+	// Gamepads are only read through the standard layout.
+	AppendGamepadIDs(ids []ebiten.GamepadID) []ebiten.GamepadID
+	IsStandardGamepadLayoutAvailable(id ebiten.GamepadID) bool
+	IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool
+	StandardGamepadAxisValue(id ebiten.GamepadID, axis ebiten.StandardGamepadAxis) float64
+}
+
+// Ebiten reads the live keyboard, mouse, touchscreen and gamepads through
+// ebiten, which maps window positions to logical ones using the game's
+// Layout.
+var Ebiten Source = ebitenSource{}
+
+type ebitenSource struct{}
+
+func (ebitenSource) IsKeyPressed(key ebiten.Key) bool { return ebiten.IsKeyPressed(key) }
+func (ebitenSource) AppendInputChars(r []rune) []rune { return ebiten.AppendInputChars(r) }
+func (ebitenSource) CursorPosition() (int, int)       { return ebiten.CursorPosition() }
+func (ebitenSource) IsMouseButtonPressed(b ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(b)
+}
+func (ebitenSource) AppendTouchIDs(ids []ebiten.TouchID) []ebiten.TouchID {
+	return ebiten.AppendTouchIDs(ids)
+}
+func (ebitenSource) TouchPosition(id ebiten.TouchID) (int, int) { return ebiten.TouchPosition(id) }
+func (ebitenSource) AppendGamepadIDs(ids []ebiten.GamepadID) []ebiten.GamepadID {
+	return ebiten.AppendGamepadIDs(ids)
+}
+func (ebitenSource) IsStandardGamepadLayoutAvailable(id ebiten.GamepadID) bool {
+	return ebiten.IsStandardGamepadLayoutAvailable(id)
+}
+func (ebitenSource) IsStandardGamepadButtonPressed(id ebiten.GamepadID, b ebiten.StandardGamepadButton) bool {
+	return ebiten.IsStandardGamepadButtonPressed(id, b)
+}
+func (ebitenSource) StandardGamepadAxisValue(id ebiten.GamepadID, a ebiten.StandardGamepadAxis) float64 {
+	return ebiten.StandardGamepadAxisValue(id, a)
+}
+
+// Keyboard polls a Source once per tick and derives edge-triggered state
+// from it, so scenes never talk to ebiten's input functions directly.
+type Keyboard struct {
+	src   Source
+	tick  int
+	keys  [ebiten.KeyMax + 1]press
+	chars []rune
+}
+
+func NewKeyboard(src Source) *Keyboard {
+	return &Keyboard{src: src}
+}
+
+// Update samples the source. Call it exactly once at the start of a tick.
+func (k *Keyboard) Update() {
+	k.tick++
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		k.keys[key].update(k.tick, k.src.IsKeyPressed(key))
+	}
+	k.chars = k.src.AppendInputChars(k.chars[:0])
+}
+
+// Chars returns the characters typed this tick, for text entry.
+func (k *Keyboard) Chars() []rune {
+	return k.chars
+}
+
+// Pressed reports whether key is down this tick.
+func (k *Keyboard) Pressed(key ebiten.Key) bool {
+	return k.key(key).held > 0
+}
+
+// JustPressed reports whether key went down this tick.
+func (k *Keyboard) JustPressed(key ebiten.Key) bool {
+	return k.key(key).justPressed()
+}
+
+// HeldTicks returns how many ticks key has been down, 0 if it is up.
+func (k *Keyboard) HeldTicks(key ebiten.Key) int {
+	return k.key(key).held
+}
+
+// HeldFor reports whether key has been down for at least d.
+func (k *Keyboard) HeldFor(key ebiten.Key, d time.Duration) bool {
+	return k.key(key).held >= max(Ticks(d), 1)
+}
+
+// LongPressed reports whether key has just been held for LongPressTicks.
+// It fires once per hold.
+func (k *Keyboard) LongPressed(key ebiten.Key) bool {
+	return k.key(key).longPressed()
+}
+
+// DoubleTapped reports whether key went down for the second time within
+// DoubleTapTicks.
+func (k *Keyboard) DoubleTapped(key ebiten.Key) bool {
+	return k.key(key).doubleTapped()
+}
+
+// Repeated is JustPressed with key repeat: it also fires every
+// RepeatInterval once key has been held for RepeatDelay.
+func (k *Keyboard) Repeated(key ebiten.Key) bool {
+	return k.key(key).repeated()
+}
+
+// Pushed returns a key that went down this tick, for "press any key"
+// prompts.
+func (k *Keyboard) Pushed() (ebiten.Key, bool) {
+	for key, p := range k.keys {
+		if p.justPressed() {
+			return ebiten.Key(key), true
+		}
+	}
+	return 0, false
+}
+
+func (k *Keyboard) key(key ebiten.Key) press {
+	if key < 0 || key > ebiten.KeyMax {
+		return press{}
+	}
+	return k.keys[key]
+}
@@ -0,0 +1,146 @@
+package input
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SwipeDistance is how far (in logical pixels) a press has to travel
+// before it counts as a swipe.
+const SwipeDistance = 12
+
+// Pointer tracks the mouse or a finger the way Keyboard tracks keys:
+// sampled once per tick, with edge-triggered clicks. Coordinates are
+// logical (320x240).
+//
+// A touch drives the pointer from the moment its finger lands until it
+// lifts; fingers that land meanwhile are ignored, so a stray palm on a
+// touchscreen can't steal a drag. Without a touch the left mouse button
+// is used.
+type Pointer struct {
+	src  Source
+	x, y int
+	tick int
+	btn  press
+	was  bool
+
+	touch    ebiten.TouchID // The finger driving the pointer
+	touching bool
+	ids      []ebiten.TouchID
+	cx, cy   int // Mouse cursor last tick
+
+	startX, startY int // Where the press (or last swipe step) began
+	swipeX, swipeY int // This tick's swipe direction
+}
+
+func NewPointer(src Source) *Pointer {
+	return &Pointer{src: src}
+}
+
+// Update samples the source. Call it exactly once at the start of a tick.
+func (p *Pointer) Update() {
+	wasTouch := p.touching
+	p.ids = p.src.AppendTouchIDs(p.ids[:0])
+	if p.touching && !slices.Contains(p.ids, p.touch) {
+		p.touching = false
+	} else if !p.touching && p.btn.held == 0 && len(p.ids) > 0 {
+		p.touch, p.touching = p.ids[0], true
+	}
+
+	// The mouse takes over again once it moves or clicks; until then a
+	// lifted finger leaves the pointer where it was
+	cx, cy := p.src.CursorPosition()
+	mouseDown := p.src.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	moved := cx != p.cx || cy != p.cy
+	p.cx, p.cy = cx, cy
+
+	down := p.touching
+	switch {
+	case p.touching:
+		p.x, p.y = p.src.TouchPosition(p.touch)
+	case wasTouch:
+		// Released where the finger lifted
+	default:
+		if moved || mouseDown || p.btn.held > 0 {
+			p.x, p.y = cx, cy
+		}
+		down = mouseDown
+	}
+
+	p.was = p.btn.held > 0
+	p.tick++
+	p.btn.update(p.tick, down)
+	p.updateSwipe()
+}
+
+// updateSwipe turns drags into steps of SwipeDistance along the dominant
+// axis, so a long drag around a corner steers twice.
+func (p *Pointer) updateSwipe() {
+	p.swipeX, p.swipeY = 0, 0
+	if p.btn.held == 1 {
+		p.startX, p.startY = p.x, p.y
+	}
+	if p.btn.held == 0 {
+		return
+	}
+	dx, dy := p.x-p.startX, p.y-p.startY
+	switch {
+	case max(abs(dx), abs(dy)) < SwipeDistance:
+		return
+	case abs(dx) > abs(dy):
+		p.swipeX = sign(dx)
+	default:
+		p.swipeY = sign(dy)
+	}
+	p.startX, p.startY = p.x, p.y
+}
+
+// Position is where the pointer is this tick.
+func (p *Pointer) Position() (x, y int) {
+	return p.x, p.y
+}
+
+// In reports whether the pointer is inside r.
+func (p *Pointer) In(r image.Rectangle) bool {
+	return image.Pt(p.x, p.y).In(r)
+}
+
+// Pressed reports whether the button is down this tick.
+func (p *Pointer) Pressed() bool { return p.btn.held > 0 }
+
+// JustPressed reports whether the button went down this tick.
+func (p *Pointer) JustPressed() bool { return p.btn.justPressed() }
+
+// JustReleased reports whether the button came up this tick.
+func (p *Pointer) JustReleased() bool { return p.was && p.btn.held == 0 }
+
+// LongPressed and DoubleTapped work like Keyboard's, for holding or
+// double-tapping in place.
+func (p *Pointer) LongPressed() bool  { return p.btn.longPressed() }
+func (p *Pointer) DoubleTapped() bool { return p.btn.doubleTapped() }
+
+// Touch reports whether a finger (rather than the mouse) drives the
+// pointer right now.
+func (p *Pointer) Touch() bool { return p.touching }
+
+// Swipe returns the direction of a swipe that completed a step this tick
+// as a unit vector along one axis, or 0, 0.
+func (p *Pointer) Swipe() (dx, dy int) {
+	return p.swipeX, p.swipeY
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return 1
+}
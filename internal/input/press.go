@@ -0,0 +1,61 @@
+package input
+
+import "time"
+
+// TPS is the tick rate the timings below are counted in.
+const TPS = 60
+
+// Timings shared by every key, button and action, in ticks.
+const (
+	LongPressTicks = TPS / 2      // Hold this long for a long press
+	DoubleTapTicks = TPS * 3 / 10 // Max gap between the presses of a double tap
+	RepeatDelay    = TPS * 2 / 5  // Held this long, a press starts repeating...
+	RepeatInterval = TPS / 10     // ... once every this many ticks
+)
+
+// Ticks converts a duration to whole ticks, rounding up so short
+// durations still need at least one tick.
+func Ticks(d time.Duration) int {
+	// A tick isn't a whole number of nanoseconds, so scale d up instead
+	return int((d*TPS + time.Second - 1) / time.Second)
+}
+
+// press follows one key, button or action from tick to tick. Keyboard,
+// Gamepad and Actions all derive their edge-triggered queries from it, so
+// "just pressed" or "repeat" mean the same thing everywhere.
+type press struct {
+	held   int  // Ticks down, 0 = up
+	last   int  // Tick the last single tap started; 0 after a double tap
+	double bool // This press completed a double tap
+}
+
+func (p *press) update(tick int, down bool) {
+	if !down {
+		p.held = 0
+		return
+	}
+	p.held++
+	if p.held > 1 {
+		return
+	}
+	// A third tap starts a new pair rather than double-tapping again
+	p.double = p.last != 0 && tick-p.last <= DoubleTapTicks
+	if p.double {
+		p.last = 0
+	} else {
+		p.last = tick
+	}
+}
+
+func (p press) justPressed() bool { return p.held == 1 }
+
+// longPressed fires once, on the tick the hold reaches LongPressTicks.
+func (p press) longPressed() bool { return p.held == LongPressTicks }
+
+func (p press) doubleTapped() bool { return p.held == 1 && p.double }
+
+// repeated fires on the press, then keeps firing while held like a
+// typematic key.
+func (p press) repeated() bool {
+	return p.held == 1 || (p.held >= RepeatDelay && (p.held-RepeatDelay)%RepeatInterval == 0)
+}
@@ -0,0 +1,75 @@
+package input
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// down is n ticks of the key held, up n ticks released.
+func down(n int) string { return strings.Repeat("#", n) }
+func up(n int) string   { return strings.Repeat(".", n) }
+
+func TestPress(t *testing.T) {
+	tests := []struct {
+		name  string
+		ticks string // One character per tick: '#' key down, '.' up
+		pred  func(k *Keyboard) bool
+		want  []int // Ticks (from 1) the predicate holds on
+	}{
+		{"just pressed", "##..#", func(k *Keyboard) bool { return k.JustPressed(ebiten.KeyA) }, []int{1, 5}},
+		{"held for", down(32) + up(1), func(k *Keyboard) bool { return k.HeldFor(ebiten.KeyA, time.Second/2) }, []int{30, 31, 32}},
+		{"long press", down(LongPressTicks + 5), func(k *Keyboard) bool { return k.LongPressed(ebiten.KeyA) }, []int{LongPressTicks}},
+		{"released before a long press", down(LongPressTicks-1) + up(1) + down(1), func(k *Keyboard) bool { return k.LongPressed(ebiten.KeyA) }, nil},
+		{"double tap at the edge of the window", down(1) + up(DoubleTapTicks-1) + down(1),
+			func(k *Keyboard) bool { return k.DoubleTapped(ebiten.KeyA) }, []int{DoubleTapTicks + 1}},
+		{"second tap a tick too late", down(1) + up(DoubleTapTicks) + down(1),
+			func(k *Keyboard) bool { return k.DoubleTapped(ebiten.KeyA) }, nil},
+		{"a third tap starts a new pair", "#.#.#.#", func(k *Keyboard) bool { return k.DoubleTapped(ebiten.KeyA) }, []int{3, 7}},
+		{"repeat", down(RepeatDelay + 2*RepeatInterval), func(k *Keyboard) bool { return k.Repeated(ebiten.KeyA) },
+			[]int{1, RepeatDelay, RepeatDelay + RepeatInterval, RepeatDelay + 2*RepeatInterval}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScript()
+			k := NewKeyboard(s)
+			var got []int
+			for i, c := range tt.ticks {
+				if c == '#' {
+					s.Press(ebiten.KeyA)
+				} else {
+					s.Release(ebiten.KeyA)
+				}
+				k.Update()
+				if tt.pred(k) {
+					got = append(got, i+1)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fired on ticks %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTicks(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want int
+	}{
+		{0, 0},
+		{time.Millisecond, 1},
+		{time.Second / 60, 1},
+		{time.Second/60 + 1, 2},
+		{time.Second / 2, 30},
+		{time.Second, 60},
+	}
+	for _, tt := range tests {
+		if got := Ticks(tt.d); got != tt.want {
+			t.Errorf("Ticks(%v) = %d, want %d", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package input
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Script is a Source whose keys, mouse and touches are driven by code,
+// for tests and other headless runs.
+type Script struct {
+	down  map[ebiten.Key]bool
+	typed []rune
+
+	x, y    int
+	buttons map[ebiten.MouseButton]bool
+	touches map[ebiten.TouchID]image.Point
+
+	pad  map[ebiten.StandardGamepadButton]bool
+	axes [ebiten.StandardGamepadAxisMax + 1]float64
+}
+
+func NewScript() *Script {
+	return &Script{
+		down:    map[ebiten.Key]bool{},
+		buttons: map[ebiten.MouseButton]bool{},
+		touches: map[ebiten.TouchID]image.Point{},
+		pad:     map[ebiten.StandardGamepadButton]bool{},
+	}
+}
+
+func (s *Script) IsKeyPressed(key ebiten.Key) bool { return s.down[key] }
+
+// AppendInputChars hands over what was typed since the last call.
+func (s *Script) AppendInputChars(r []rune) []rune {
+	r = append(r, s.typed...)
+	s.typed = s.typed[:0]
+	return r
+}
+
+func (s *Script) CursorPosition() (int, int) { return s.x, s.y }
+
+func (s *Script) IsMouseButtonPressed(b ebiten.MouseButton) bool { return s.buttons[b] }
+
+// Type queues text to arrive as input characters on the next tick.
+func (s *Script) Type(text string) {
+	s.typed = append(s.typed, []rune(text)...)
+}
+
+// MoveTo puts the mouse cursor at logical x, y.
+func (s *Script) MoveTo(x, y int) {
+	s.x, s.y = x, y
+}
+
+// PressButton and ReleaseButton hold and let go of mouse buttons.
+func (s *Script) PressButton(b ebiten.MouseButton) {
+	s.buttons[b] = true
+}
+
+func (s *Script) ReleaseButton(b ebiten.MouseButton) {
+	delete(s.buttons, b)
+}
+
+// AppendTouchIDs lists the fingers down, oldest ID first.
+func (s *Script) AppendTouchIDs(ids []ebiten.TouchID) []ebiten.TouchID {
+	start := len(ids)
+	for id := range s.touches {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids[start:])
+	return ids
+}
+
+func (s *Script) TouchPosition(id ebiten.TouchID) (int, int) {
+	p := s.touches[id]
+	return p.X, p.Y
+}
+
+// Touch puts finger id down at (or drags it to) logical x, y; Lift
+// takes it off the screen.
+func (s *Script) Touch(id ebiten.TouchID, x, y int) {
+	s.touches[id] = image.Pt(x, y)
+}
+
+func (s *Script) Lift(id ebiten.TouchID) {
+	delete(s.touches, id)
+}
+
+// The script has a single standard gamepad, ID 0, always plugged in.
+func (s *Script) AppendGamepadIDs(ids []ebiten.GamepadID) []ebiten.GamepadID {
+	return append(ids, 0)
+}
+
+func (s *Script) IsStandardGamepadLayoutAvailable(id ebiten.GamepadID) bool { return id == 0 }
+
+func (s *Script) IsStandardGamepadButtonPressed(id ebiten.GamepadID, b ebiten.StandardGamepadButton) bool {
+	return id == 0 && s.pad[b]
+}
+
+func (s *Script) StandardGamepadAxisValue(id ebiten.GamepadID, a ebiten.StandardGamepadAxis) float64 {
+	if id != 0 || a < 0 || a > ebiten.StandardGamepadAxisMax {
+		return 0
+	}
+	return s.axes[a]
+}
+
+// PressPad and ReleasePad hold and let go of gamepad buttons; TiltStick
+// sets a stick axis (-1 to 1).
+func (s *Script) PressPad(buttons ...ebiten.StandardGamepadButton) {
+	for _, b := range buttons {
+		s.pad[b] = true
+	}
+}
+
+func (s *Script) ReleasePad(buttons ...ebiten.StandardGamepadButton) {
+	for _, b := range buttons {
+		delete(s.pad, b)
+	}
+}
+
+func (s *Script) TiltStick(a ebiten.StandardGamepadAxis, v float64) {
+	s.axes[a] = v
+}
+
+// Press holds keys down until they are released.
+func (s *Script) Press(keys ...ebiten.Key) {
+	for _, k := range keys {
+		s.down[k] = true
+	}
+}
+
+func (s *Script) Release(keys ...ebiten.Key) {
+	for _, k := range keys {
+		delete(s.down, k)
+	}
+}
+
+func (s *Script) ReleaseAll() {
+	clear(s.down)
+	clear(s.buttons)
+	clear(s.touches)
+	clear(s.pad)
+	clear(s.axes[:])
+}
@@ -0,0 +1,175 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// loopChance is how likely each remaining inside wall is to be knocked
+// through once the maze is carved, for extra loops.
+const loopChance = 0.1
+
+// Generate builds a w by h board from seed; the same seed and size
+// always give the same board. The left half is carved as a maze on a
+// grid of cells, its dead ends are opened up into loops, and the right
+// half mirrors it. The ghosts start in the middle, the panda at the
+// bottom, a pellet sits in each corner and a tunnel crosses one row.
+//
+// w must be a multiple of 4 and h odd, so that the cells line up with
+// the mirror and the border; ghosts is 1 to 4.
+func Generate(seed int64, w, h, ghosts int) (*Level, error) {
+	switch {
+	case w%4 != 0 || w < 12:
+		return nil, fmt.Errorf("maze: generate: width %d is not a multiple of 4 from 12", w)
+	case h%2 != 1 || h < 9:
+		return nil, fmt.Errorf("maze: generate: height %d is not odd from 9", h)
+	case ghosts < 1 || ghosts > 4:
+		return nil, fmt.Errorf("maze: generate: %d ghosts, want 1 to 4", ghosts)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	l := &Level{Name: fmt.Sprintf("Maze %d", seed), W: w, H: h, Tiles: make([]Tile, w*h)}
+	for i := range l.Tiles {
+		l.Tiles[i] = Wall
+	}
+	g := &grid{l: l, cw: w / 4, ch: (h - 1) / 2}
+	for cy := range g.ch {
+		for cx := range g.cw {
+			g.set(g.tile(cx, cy), Floor)
+		}
+	}
+	g.carve(rng)
+	g.braid(rng)
+	g.loops(rng)
+
+	// Spawns: ghosts side by side across the middle row, the panda at the
+	// bottom, both next to the mirror line
+	mid, half := g.tile(0, g.ch/2).Y, w/2
+	for _, x := range []int{half - 1, half, half - 3, half + 2}[:ghosts] {
+		l.Ghosts = append(l.Ghosts, Point{x, mid})
+	}
+	l.Player = Point{half - 1, h - 2}
+	for i, t := range l.Tiles {
+		if t == Floor {
+			l.Tiles[i] = Dot
+		}
+	}
+	for _, p := range append([]Point{l.Player}, l.Ghosts...) {
+		l.Set(p, Floor)
+	}
+	for _, p := range []Point{g.tile(0, 0), g.tile(0, g.ch-1)} {
+		g.set(p, Pellet)
+	}
+	// The tunnel goes through a row between the corners
+	g.set(Point{0, g.tile(0, 1+rng.Intn(g.ch-2)).Y}, Floor)
+
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// grid carves the left half of a board as cells at odd tiles, with a
+// wall tile between each pair of neighbours; every change is mirrored
+// onto the right half.
+type grid struct {
+	l      *Level
+	cw, ch int // Cells across the half, and down
+}
+
+func (g *grid) tile(cx, cy int) Point { return Point{2*cx + 1, 2*cy + 1} }
+
+func (g *grid) set(p Point, t Tile) {
+	g.l.Set(p, t)
+	g.l.Set(Point{g.l.W - 1 - p.X, p.Y}, t)
+}
+
+// between is the wall tile between cell (cx, cy) and its neighbour in
+// direction d; it may be the mirror line itself.
+func (g *grid) between(cx, cy int, d Point) Point {
+	return g.tile(cx, cy).Add(d)
+}
+
+func (g *grid) inside(cx, cy int) bool {
+	return cx >= 0 && cx < g.cw && cy >= 0 && cy < g.ch
+}
+
+var steps = []Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// carve digs a spanning tree through the cells, depth first, so every
+// cell is reachable before any loops are added.
+func (g *grid) carve(rng *rand.Rand) {
+	seen := make([]bool, g.cw*g.ch)
+	start := Point{rng.Intn(g.cw), rng.Intn(g.ch)}
+	stack := []Point{start}
+	seen[start.Y*g.cw+start.X] = true
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		var next []Point
+		for _, d := range steps {
+			n := c.Add(d)
+			if g.inside(n.X, n.Y) && !seen[n.Y*g.cw+n.X] {
+				next = append(next, d)
+			}
+		}
+		if len(next) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		d := next[rng.Intn(len(next))]
+		n := c.Add(d)
+		g.set(g.between(c.X, c.Y, d), Floor)
+		seen[n.Y*g.cw+n.X] = true
+		stack = append(stack, n)
+	}
+}
+
+// exits counts the ways out of a cell, including across the mirror line.
+func (g *grid) exits(cx, cy int) int {
+	n := 0
+	for _, d := range steps {
+		if g.l.At(g.between(cx, cy, d)) != Wall {
+			n++
+		}
+	}
+	return n
+}
+
+// braid opens every dead end into a neighbour, preferring one that is a
+// dead end too, so the gophers can never corner the panda in one.
+func (g *grid) braid(rng *rand.Rand) {
+	for _, i := range rng.Perm(g.cw * g.ch) {
+		cx, cy := i%g.cw, i/g.cw
+		if g.exits(cx, cy) > 1 {
+			continue
+		}
+		var walls, deadEnds []Point
+		for _, d := range steps {
+			n := Point{cx, cy}.Add(d)
+			if !g.inside(n.X, n.Y) || g.l.At(g.between(cx, cy, d)) != Wall {
+				continue
+			}
+			walls = append(walls, d)
+			if g.exits(n.X, n.Y) == 1 {
+				deadEnds = append(deadEnds, d)
+			}
+		}
+		if len(deadEnds) > 0 {
+			walls = deadEnds
+		}
+		d := walls[rng.Intn(len(walls))]
+		g.set(g.between(cx, cy, d), Floor)
+	}
+}
+
+// loops knocks through a few more inside walls at random.
+func (g *grid) loops(rng *rand.Rand) {
+	for cy := range g.ch {
+		for cx := range g.cw {
+			for _, d := range steps[1:3] { // Right and down, so each wall comes up once
+				if g.inside(cx+d.X, cy+d.Y) && rng.Float64() < loopChance {
+					g.set(g.between(cx, cy, d), Floor)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package maze
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerateSameSeed(t *testing.T) {
+	a, err := Generate(42, 20, 13, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Generate(42, 20, 13, 4)
+	if !slices.Equal(a.Tiles, b.Tiles) || a.Player != b.Player || !slices.Equal(a.Ghosts, b.Ghosts) {
+		t.Error("seed 42 gave two different boards")
+	}
+	c, _ := Generate(43, 20, 13, 4)
+	if slices.Equal(a.Tiles, c.Tiles) {
+		t.Error("seeds 42 and 43 gave the same board")
+	}
+}
+
+func TestGenerateBoards(t *testing.T) {
+	tests := []struct {
+		w, h, ghosts int
+	}{
+		{12, 9, 1},
+		{20, 13, 2},
+		{28, 21, 4},
+	}
+	for _, tt := range tests {
+		for seed := range int64(50) {
+			l, err := Generate(seed, tt.w, tt.h, tt.ghosts)
+			if err != nil {
+				t.Fatalf("%dx%d seed %d: %v", tt.w, tt.h, seed, err)
+			}
+			if l.W != tt.w || l.H != tt.h || len(l.Ghosts) != tt.ghosts || l.Dots() == 0 {
+				t.Fatalf("%dx%d seed %d: got %dx%d with %d ghosts, %d dots", tt.w, tt.h, seed, l.W, l.H, len(l.Ghosts), l.Dots())
+			}
+			// The right half's walls mirror the left's
+			for y := range l.H {
+				for x := range l.W / 2 {
+					if (l.At(Point{x, y}) == Wall) != (l.At(Point{l.W - 1 - x, y}) == Wall) {
+						t.Fatalf("%dx%d seed %d: not mirrored at %d,%d", tt.w, tt.h, seed, x, y)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateBadSizes(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h, ghosts int
+	}{
+		{"width not a multiple of 4", 18, 13, 2},
+		{"too narrow", 8, 13, 2},
+		{"even height", 20, 12, 2},
+		{"too short", 20, 7, 2},
+		{"no ghosts", 20, 13, 0},
+		{"too many ghosts", 20, 13, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(1, tt.w, tt.h, tt.ghosts); err == nil {
+				t.Errorf("Generate(%d, %d, %d) succeeded", tt.w, tt.h, tt.ghosts)
+			}
+		})
+	}
+}
+
+func TestValidateUnreachable(t *testing.T) {
+	l, err := Generate(7, 20, 13, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wall in the panda
+	for _, d := range steps {
+		l.Set(l.Player.Add(d), Wall)
+	}
+	if err := l.Validate(); err == nil {
+		t.Error("a walled-in panda validated")
+	}
+}
@@ -0,0 +1,256 @@
+// Package maze holds Panda-Man's boards: the tile grid, where everyone
+// starts, and the text format levels are written in.
+//
+// A level file is a grid of characters, one per tile:
+//
+//	#  wall
+//	.  bamboo dot
+//	o  power pellet
+//	   (space) empty floor
+//	P  the panda's start
+//	G  a ghost's start (one per ghost)
+//
+// Lines starting with ';' are comments, and "name: ..." names the level.
+// Every row must be the same width. A row or column left open at both
+// edges is a tunnel: walking off one side comes back on the other. Every
+// dot, pellet and ghost must be reachable from the panda's start.
+package maze
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+type Tile uint8
+
+const (
+	Floor Tile = iota
+	Wall
+	Dot
+	Pellet
+)
+
+// Point is a tile position.
+type Point struct{ X, Y int }
+
+func (p Point) Add(q Point) Point { return Point{p.X + q.X, p.Y + q.Y} }
+
+type Level struct {
+	Name   string
+	W, H   int
+	Tiles  []Tile // Row by row
+	Player Point
+	Ghosts []Point
+}
+
+// At returns the tile at p, wrapping through tunnels; outside the grid
+// anywhere else is wall.
+func (l *Level) At(p Point) Tile {
+	p, ok := l.Wrap(p)
+	if !ok {
+		return Wall
+	}
+	return l.Tiles[p.Y*l.W+p.X]
+}
+
+func (l *Level) Set(p Point, t Tile) {
+	if p, ok := l.Wrap(p); ok {
+		l.Tiles[p.Y*l.W+p.X] = t
+	}
+}
+
+func (l *Level) In(p Point) bool {
+	return p.X >= 0 && p.X < l.W && p.Y >= 0 && p.Y < l.H
+}
+
+// Wrap brings a point one step off the grid back in on the far side,
+// which is how tunnels work. It reports false for points outside the grid
+// that no tunnel leads to.
+func (l *Level) Wrap(p Point) (Point, bool) {
+	q := Point{(p.X + l.W) % l.W, (p.Y + l.H) % l.H}
+	if q == p {
+		return p, true
+	}
+	if p.X < -1 || p.X > l.W || p.Y < -1 || p.Y > l.H {
+		return p, false
+	}
+	// Only where the edge we left is open too
+	from := Point{min(max(p.X, 0), l.W-1), min(max(p.Y, 0), l.H-1)}
+	if l.Tiles[from.Y*l.W+from.X] == Wall || l.Tiles[q.Y*l.W+q.X] == Wall {
+		return p, false
+	}
+	return q, true
+}
+
+// Dots counts what is left to eat: dots and power pellets.
+func (l *Level) Dots() int {
+	n := 0
+	for _, t := range l.Tiles {
+		if t == Dot || t == Pellet {
+			n++
+		}
+	}
+	return n
+}
+
+// Clone copies the level so a game can eat the dots of its copy.
+func (l *Level) Clone() *Level {
+	c := *l
+	c.Tiles = append([]Tile(nil), l.Tiles...)
+	c.Ghosts = append([]Point(nil), l.Ghosts...)
+	return &c
+}
+
+// --- Parsing ---
+
+var tileChars = map[rune]Tile{'#': Wall, '.': Dot, 'o': Pellet, ' ': Floor, 'P': Floor, 'G': Floor}
+
+// Parse reads a level file; name is used in error messages and as the
+// level's name if it doesn't give one.
+func Parse(name string, r io.Reader) (*Level, error) {
+	l := &Level{Name: strings.TrimSuffix(path.Base(name), path.Ext(name))}
+	players := 0
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if strings.HasPrefix(text, ";") {
+			continue
+		}
+		if n, ok := strings.CutPrefix(text, "name:"); ok {
+			l.Name = strings.TrimSpace(n)
+			continue
+		}
+		if l.H == 0 && strings.TrimSpace(text) == "" {
+			continue // Blank lines before the grid
+		}
+		if strings.TrimSpace(text) == "" {
+			break // ... and after it
+		}
+		if l.H == 0 {
+			l.W = len([]rune(text))
+		}
+		if n := len([]rune(text)); n != l.W {
+			return nil, fmt.Errorf("maze: %s:%d: row is %d wide, want %d", name, line, n, l.W)
+		}
+		for x, c := range []rune(text) {
+			t, ok := tileChars[c]
+			if !ok {
+				return nil, fmt.Errorf("maze: %s:%d: unknown tile %q", name, line, c)
+			}
+			switch c {
+			case 'P':
+				l.Player = Point{x, l.H}
+				players++
+			case 'G':
+				l.Ghosts = append(l.Ghosts, Point{x, l.H})
+			}
+			l.Tiles = append(l.Tiles, t)
+		}
+		l.H++
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("maze: %s: %w", name, err)
+	}
+
+	switch {
+	case l.H == 0:
+		return nil, fmt.Errorf("maze: %s: no grid", name)
+	case players != 1:
+		return nil, fmt.Errorf("maze: %s: want one P, have %d", name, players)
+	case len(l.Ghosts) == 0:
+		return nil, fmt.Errorf("maze: %s: no G", name)
+	case l.Dots() == 0:
+		return nil, fmt.Errorf("maze: %s: nothing to eat", name)
+	}
+	// An opening on one edge must lead somewhere on the other
+	for y := range l.H {
+		if (l.Tiles[y*l.W] == Wall) != (l.Tiles[y*l.W+l.W-1] == Wall) {
+			return nil, fmt.Errorf("maze: %s: row %d is open on one side only", name, y+1)
+		}
+	}
+	for x := range l.W {
+		if (l.Tiles[x] == Wall) != (l.Tiles[(l.H-1)*l.W+x] == Wall) {
+			return nil, fmt.Errorf("maze: %s: column %d is open on one side only", name, x+1)
+		}
+	}
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Validate checks that the level can be played out: every dot and pellet,
+// and every ghost's start, can be reached from the panda's start.
+func (l *Level) Validate() error {
+	if !l.In(l.Player) || l.At(l.Player) == Wall {
+		return fmt.Errorf("maze: %s: the panda starts in a wall", l.Name)
+	}
+	seen := l.reach(l.Player)
+	for i, t := range l.Tiles {
+		if (t == Dot || t == Pellet) && !seen[i] {
+			return fmt.Errorf("maze: %s: dot at %d,%d can't be reached", l.Name, i%l.W+1, i/l.W+1)
+		}
+	}
+	for _, g := range l.Ghosts {
+		if !l.In(g) || !seen[g.Y*l.W+g.X] {
+			return fmt.Errorf("maze: %s: ghost at %d,%d can't be reached", l.Name, g.X+1, g.Y+1)
+		}
+	}
+	return nil
+}
+
+// reach marks, by tile index, everywhere that can be walked to from p,
+// tunnels included.
+func (l *Level) reach(p Point) []bool {
+	seen := make([]bool, len(l.Tiles))
+	seen[p.Y*l.W+p.X] = true
+	queue := []Point{p}
+	for len(queue) > 0 {
+		at := queue[0]
+		queue = queue[1:]
+		for _, d := range steps {
+			n, ok := l.Wrap(at.Add(d))
+			if !ok || l.At(n) == Wall || seen[n.Y*l.W+n.X] {
+				continue
+			}
+			seen[n.Y*l.W+n.X] = true
+			queue = append(queue, n)
+		}
+	}
+	return seen
+}
+
+// --- Campaign ---
+
+//go:embed levels
+var levelFiles embed.FS
+
+// Campaign parses the built-in levels in play order (by file name). A
+// broken file is an error; the others still come back.
+func Campaign() ([]*Level, error) {
+	var levels []*Level
+	var errs []error
+	entries, _ := fs.ReadDir(levelFiles, "levels")
+	for _, e := range entries {
+		name := "levels/" + e.Name()
+		f, err := levelFiles.Open(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		l, err := Parse(name, f)
+		f.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		levels = append(levels, l)
+	}
+	return levels, errors.Join(errs...)
+}
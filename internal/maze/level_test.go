@@ -0,0 +1,76 @@
+package maze
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	src := strings.Join([]string{
+		"; A comment",
+		"name: Test Board",
+		"",
+		"#######",
+		"#P..oG#",
+		"   .   ",
+		"#.....#",
+		"#######",
+		"",
+		"trailing notes are ignored",
+	}, "\n")
+	l, err := Parse("levels/9-test.txt", strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "Test Board" || l.W != 7 || l.H != 5 {
+		t.Errorf("got %q %dx%d, want Test Board 7x5", l.Name, l.W, l.H)
+	}
+	if l.Player != (Point{1, 1}) || len(l.Ghosts) != 1 || l.Ghosts[0] != (Point{5, 1}) {
+		t.Errorf("player %v, ghosts %v", l.Player, l.Ghosts)
+	}
+	if l.Dots() != 9 || l.At(Point{4, 1}) != Pellet {
+		t.Errorf("%d dots, %v at 4,1", l.Dots(), l.At(Point{4, 1}))
+	}
+	// Row 3 is a tunnel
+	if got, ok := l.Wrap(Point{-1, 2}); !ok || got != (Point{6, 2}) {
+		t.Errorf("Wrap(-1,2) = %v, %v; want 6,2 through the tunnel", got, ok)
+	}
+	if _, ok := l.Wrap(Point{-1, 1}); ok {
+		t.Error("walked off the board through a wall")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, grid, want string
+	}{
+		{"empty", "; nothing\n", "no grid"},
+		{"ragged", "#####\n#P.G#\n####\n", "m.txt:3: row is 4 wide, want 5"},
+		{"unknown tile", "#####\n#P.X#\n#####\n", `m.txt:2: unknown tile 'X'`},
+		{"no panda", "#####\n#..G#\n#####\n", "want one P, have 0"},
+		{"two pandas", "#####\n#PPG#\n#####\n", "want one P, have 2"},
+		{"no ghost", "#####\n#P..#\n#####\n", "no G"},
+		{"nothing to eat", "#####\n#P G#\n#####\n", "nothing to eat"},
+		{"one-sided tunnel", "#####\n P.G#\n#####\n", "row 2 is open on one side only"},
+		{"unreachable dot", "#######\n#P.G#.#\n#######\n", "dot at 6,2 can't be reached"},
+		{"unreachable ghost", "#######\n#P..#G#\n#######\n", "ghost at 6,2 can't be reached"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("m.txt", strings.NewReader(tt.grid))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCampaign(t *testing.T) {
+	levels, err := Campaign()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) == 0 || levels[0].Name != "Bamboo Grove" {
+		t.Errorf("got %d levels, want the grove first", len(levels))
+	}
+}
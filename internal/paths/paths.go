@@ -0,0 +1,151 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const appDir = "panda"
+
+// Save file names, the same in every directory layout.
+const (
+	SettingsName = "settings.json"
+	StatsName    = "panda_stats.json"
+	HistoryName  = "focus_history.jsonl"
+)
+
+// Paths says where the save files live: settings under the user's config
+// directory, stats and history under the data directory.
+type Paths struct {
+	ConfigDir string
+	DataDir   string
+}
+
+func (p Paths) Settings() string { return filepath.Join(p.ConfigDir, SettingsName) }
+func (p Paths) Stats() string    { return filepath.Join(p.DataDir, StatsName) }
+func (p Paths) History() string  { return filepath.Join(p.DataDir, HistoryName) }
+
+// Resolve picks the save directories. A non-empty override (--data-dir)
+// puts every file in that one directory; otherwise settings go to
+// os.UserConfigDir()/panda and data to $XDG_DATA_HOME/panda (default
+// ~/.local/share/panda). Platforms without an XDG data dir keep both
+// under the config dir. The directories are created if missing.
+func Resolve(override string) (Paths, error) {
+	var p Paths
+	if override != "" {
+		dir, err := filepath.Abs(override)
+		if err != nil {
+			return p, err
+		}
+		p = Paths{ConfigDir: dir, DataDir: dir}
+	} else {
+		cfg, err := os.UserConfigDir()
+		if err != nil {
+			return p, err
+		}
+		data, err := dataHome()
+		if err != nil {
+			data = cfg
+		}
+		p = Paths{ConfigDir: filepath.Join(cfg, appDir), DataDir: filepath.Join(data, appDir)}
+	}
+	for _, dir := range []string{p.ConfigDir, p.DataDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+func dataHome() (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9", "android":
+		return os.UserConfigDir()
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		if !filepath.IsAbs(dir) {
+			return "", errors.New("paths: $XDG_DATA_HOME is relative")
+		}
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// LegacyMarker is the file, in the config directory, that records that
+// MigrateLegacy has run.
+const LegacyMarker = ".legacy-migrated"
+
+// MigrateLegacy copies save files left in dir by older versions (which
+// wrote to the working directory) to their new homes. It runs once:
+// afterwards the marker in the config directory makes it a no-op. Only
+// files that ours accepts are taken, so another program's settings.json
+// is left alone, and files already at the destination win. The legacy
+// files themselves stay where they are. It returns the destinations
+// written.
+func (p Paths) MigrateLegacy(dir string, ours func(name string, data []byte) bool) ([]string, error) {
+	marker := filepath.Join(p.ConfigDir, LegacyMarker)
+	if _, err := os.Stat(marker); err == nil {
+		return nil, nil
+	}
+	var copied []string
+	var errs []error
+	for name, dst := range map[string]string{
+		SettingsName: p.Settings(),
+		StatsName:    p.Stats(),
+		HistoryName:  p.History(),
+	} {
+		src := filepath.Join(dir, name)
+		if same(src, dst) {
+			continue
+		}
+		data, err := os.ReadFile(src)
+		if err != nil || !ours(name, data) {
+			continue
+		}
+		if _, err := os.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := writeNew(dst, data); err != nil {
+			errs = append(errs, fmt.Errorf("paths: migrating %s: %w", src, err))
+			continue
+		}
+		copied = append(copied, dst)
+	}
+	// A failed copy is tried again next time
+	if len(errs) == 0 {
+		if err := os.WriteFile(marker, nil, 0644); err != nil {
+			errs = append(errs, fmt.Errorf("paths: %w", err))
+		}
+	}
+	return copied, errors.Join(errs...)
+}
+
+func same(a, b string) bool {
+	a, errA := filepath.Abs(a)
+	b, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && a == b
+}
+
+// writeNew creates dst with data, failing if it already exists.
+func writeNew(dst string, data []byte) error {
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dst)
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveOverride(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "saves")
+	p, err := Resolve(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Settings() != filepath.Join(dir, SettingsName) || p.Stats() != filepath.Join(dir, StatsName) {
+		t.Errorf("got %+v, want everything in %s", p, dir)
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		t.Errorf("%s not created: %v", dir, err)
+	}
+}
+
+func TestResolveXDG(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG layout is for Linux and the BSDs")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+	tests := []struct {
+		dataHome string
+		want     string
+	}{
+		{"", filepath.Join(home, ".local", "share", appDir)},
+		{filepath.Join(home, "data"), filepath.Join(home, "data", appDir)},
+		{"relative/data", filepath.Join(home, "cfg", appDir)}, // Ignored, as the spec says
+	}
+	for _, tt := range tests {
+		t.Setenv("XDG_DATA_HOME", tt.dataHome)
+		p, err := Resolve("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.ConfigDir != filepath.Join(home, "cfg", appDir) || p.DataDir != tt.want {
+			t.Errorf("XDG_DATA_HOME=%q: got %+v, want data in %s", tt.dataHome, p, tt.want)
+		}
+	}
+}
+
+// ours accepts files that start the way this app's do in the tests.
+func ours(name string, data []byte) bool { return strings.HasPrefix(string(data), "panda") }
+
+func TestMigrateLegacy(t *testing.T) {
+	tests := []struct {
+		name     string
+		legacy   map[string]string // In the old working directory
+		existing map[string]string // Already in the new place
+		want     map[string]string // In the new place afterwards
+	}{
+		{
+			name:   "copies ours",
+			legacy: map[string]string{SettingsName: "panda settings", StatsName: "panda stats", HistoryName: "panda history"},
+			want:   map[string]string{SettingsName: "panda settings", StatsName: "panda stats", HistoryName: "panda history"},
+		},
+		{
+			name:   "leaves another program's file",
+			legacy: map[string]string{SettingsName: `{"editor.tabSize": 4}`, StatsName: "panda stats"},
+			want:   map[string]string{StatsName: "panda stats"},
+		},
+		{
+			name:     "the new file wins",
+			legacy:   map[string]string{SettingsName: "panda old", StatsName: "panda old stats"},
+			existing: map[string]string{SettingsName: "panda new"},
+			want:     map[string]string{SettingsName: "panda new", StatsName: "panda old stats"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, p := t.TempDir(), Paths{ConfigDir: t.TempDir(), DataDir: t.TempDir()}
+			dst := map[string]string{SettingsName: p.Settings(), StatsName: p.Stats(), HistoryName: p.History()}
+			for name, data := range tt.legacy {
+				writeFile(t, filepath.Join(old, name), data)
+			}
+			for name, data := range tt.existing {
+				writeFile(t, dst[name], data)
+			}
+
+			copied, err := p.MigrateLegacy(old, ours)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, path := range dst {
+				got, _ := os.ReadFile(path)
+				if string(got) != tt.want[name] {
+					t.Errorf("%s = %q, want %q", name, got, tt.want[name])
+				}
+				_, kept := tt.existing[name]
+				if listed, want := slices.Contains(copied, path), tt.want[name] != "" && !kept; listed != want {
+					t.Errorf("%s listed as copied: %v, want %v", name, listed, want)
+				}
+			}
+			// The originals are copied, not moved
+			for name, data := range tt.legacy {
+				if got, _ := os.ReadFile(filepath.Join(old, name)); string(got) != data {
+					t.Errorf("legacy %s = %q, want it untouched", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateLegacyOnce(t *testing.T) {
+	old, p := t.TempDir(), Paths{ConfigDir: t.TempDir(), DataDir: t.TempDir()}
+	if copied, err := p.MigrateLegacy(old, ours); len(copied) != 0 || err != nil {
+		t.Fatalf("nothing to migrate: got %v, %v", copied, err)
+	}
+	// Files turning up later, e.g. starting panda in some other directory,
+	// are not picked up
+	writeFile(t, filepath.Join(old, StatsName), "panda stats")
+	if copied, err := p.MigrateLegacy(old, ours); len(copied) != 0 || err != nil {
+		t.Errorf("second run: got %v, %v; want nothing", copied, err)
+	}
+}
+
+func TestMigrateLegacySameDir(t *testing.T) {
+	dir := t.TempDir()
+	p := Paths{ConfigDir: dir, DataDir: dir}
+	writeFile(t, p.Stats(), "panda stats")
+	if copied, err := p.MigrateLegacy(dir, ours); len(copied) != 0 || err != nil {
+		t.Errorf("got %v, %v; want nothing for --data-dir .", copied, err)
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package pixeltext draws UI text in the embedded 12px bitmap font (the
+// one ebiten's debug print is styled after) through text/v2, with
+// alignment, word wrapping and drop shadows.
+package pixeltext
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/bitmapfont/v4"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Face is the pixel font; glyphs are 6px wide (ASCII) and 12px tall.
+var Face = text.NewGoXFace(bitmapfont.Face)
+
+// LineHeight is the distance between baselines, matching DebugPrint;
+// GlyphHeight is how much of that the letters use.
+const (
+	LineHeight  = 16
+	GlyphHeight = 12
+)
+
+type Align int
+
+const (
+	Left Align = iota
+	Center
+	Right
+)
+
+// Style says how to draw a string. The zero Style is white,
+// left-aligned, unwrapped and without a shadow.
+type Style struct {
+	Color  color.Color // nil means white
+	Shadow color.Color // Drawn 1px down-right first; nil for none
+	Align  Align       // x is the left edge, centre or right edge
+	Width  float64     // Wrap lines longer than this; 0 disables
+}
+
+func (s Style) WithAlign(a Align) Style       { s.Align = a; return s }
+func (s Style) WithColor(c color.Color) Style { s.Color = c; return s }
+func (s Style) WithWidth(w float64) Style     { s.Width = w; return s }
+
+// Draw prints str with its first line's top at y. Newlines start new
+// lines; with a Width, long lines are also wrapped at spaces.
+func Draw(dst *ebiten.Image, str string, x, y float64, st Style) {
+	lines := Lines(str, st.Width)
+	if st.Shadow != nil {
+		draw(dst, lines, x+1, y+1, st.Align, st.Shadow)
+	}
+	c := st.Color
+	if c == nil {
+		c = color.White
+	}
+	draw(dst, lines, x, y, st.Align, c)
+}
+
+func draw(dst *ebiten.Image, lines []string, x, y float64, a Align, c color.Color) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(c)
+	op.LineSpacing = LineHeight
+	switch a {
+	case Center:
+		op.PrimaryAlign = text.AlignCenter
+	case Right:
+		op.PrimaryAlign = text.AlignEnd
+	}
+	text.Draw(dst, strings.Join(lines, "\n"), Face, op)
+}
+
+// Measure returns the size str takes when drawn with st.
+func Measure(str string, st Style) (w, h float64) {
+	lines := Lines(str, st.Width)
+	for _, l := range lines {
+		w = max(w, Width(l))
+	}
+	return w, float64(len(lines)) * LineHeight
+}
+
+// Width of a single line.
+func Width(line string) float64 {
+	return text.Advance(line, Face)
+}
+
+// Lines splits str at newlines and, if width > 0, wraps each line at
+// spaces so it fits. A word too long for a line gets a line of its own.
+func Lines(str string, width float64) []string {
+	var out []string
+	for _, para := range strings.Split(str, "\n") {
+		if width <= 0 || Width(para) <= width {
+			out = append(out, para)
+			continue
+		}
+		line := ""
+		for _, word := range strings.Fields(para) {
+			next := word
+			if line != "" {
+				next = line + " " + word
+			}
+			if line != "" && Width(next) > width {
+				out = append(out, line)
+				next = word
+			}
+			line = next
+		}
+		out = append(out, line)
+	}
+	return out
+}
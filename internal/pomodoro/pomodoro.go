@@ -0,0 +1,303 @@
+package pomodoro
+
+import "time"
+
+// Phase is one step of the Pomodoro cycle.
+type Phase int
+
+const (
+	PhaseWork Phase = iota
+	PhaseShortBreak
+	PhaseLongBreak
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseShortBreak:
+		return "short_break"
+	case PhaseLongBreak:
+		return "long_break"
+	}
+	return "work"
+}
+
+func (p Phase) IsBreak() bool { return p != PhaseWork }
+
+// Status says whether the current phase is counting down.
+type Status int
+
+const (
+	StatusStopped Status = iota // Phase loaded, waiting for Start
+	StatusRunning
+	StatusPaused
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusRunning:
+		return "running"
+	case StatusPaused:
+		return "paused"
+	}
+	return "stopped"
+}
+
+// Config is the user's cycle setup, persisted in settings.
+type Config struct {
+	WorkMinutes       int  `json:"work_minutes"`
+	ShortBreakMinutes int  `json:"short_break_minutes"`
+	LongBreakMinutes  int  `json:"long_break_minutes"`
+	LongBreakEvery    int  `json:"long_break_every"` // Work sessions per long break
+	AutoStart         bool `json:"auto_start"`       // Start the next phase without waiting
+}
+
+func DefaultConfig() Config {
+	return Config{
+		WorkMinutes:       25,
+		ShortBreakMinutes: 5,
+		LongBreakMinutes:  15,
+		LongBreakEvery:    4,
+	}
+}
+
+// WithDefaults fills unset (zero or negative) fields from DefaultConfig,
+// so older settings files without a cycle config still work.
+func (c Config) WithDefaults() Config {
+	d := DefaultConfig()
+	if c.WorkMinutes <= 0 {
+		c.WorkMinutes = d.WorkMinutes
+	}
+	if c.ShortBreakMinutes <= 0 {
+		c.ShortBreakMinutes = d.ShortBreakMinutes
+	}
+	if c.LongBreakMinutes <= 0 {
+		c.LongBreakMinutes = d.LongBreakMinutes
+	}
+	if c.LongBreakEvery <= 0 {
+		c.LongBreakEvery = d.LongBreakEvery
+	}
+	return c
+}
+
+// Length returns how long phase p lasts under this config.
+func (c Config) Length(p Phase) time.Duration {
+	switch p {
+	case PhaseShortBreak:
+		return time.Duration(c.ShortBreakMinutes) * time.Minute
+	case PhaseLongBreak:
+		return time.Duration(c.LongBreakMinutes) * time.Minute
+	}
+	return time.Duration(c.WorkMinutes) * time.Minute
+}
+
+type EventKind int
+
+const (
+	EventStarted   EventKind = iota // Phase began counting down
+	EventPaused                     // Phase paused mid-way
+	EventResumed                    // Paused phase continues
+	EventCompleted                  // Phase ran to zero
+	EventSkipped                    // Phase cut short by Skip
+	EventReset                      // Phase abandoned by Reset
+)
+
+func (k EventKind) String() string {
+	return [...]string{"started", "paused", "resumed", "completed", "skipped", "reset"}[k]
+}
+
+// Event reports a transition. Elapsed is how much of the phase had run
+// (excluding pauses) when the event fired.
+type Event struct {
+	Kind    EventKind
+	Phase   Phase
+	At      time.Time
+	Length  time.Duration
+	Elapsed time.Duration
+}
+
+// Engine runs the Pomodoro cycle: work, short breaks and a long break
+// every LongBreakEvery sessions. It does not read the clock itself;
+// callers pass the current time to every command and to Update.
+type Engine struct {
+	Config    Config
+	Phase     Phase
+	Status    Status
+	Remaining time.Duration
+	Sessions  int // Completed work sessions since the last Reset
+
+	length    time.Duration // Of the current phase, fixed once it starts
+	last      time.Time
+	listeners []func(Event)
+}
+
+func New(cfg Config) *Engine {
+	e := &Engine{Config: cfg.WithDefaults(), Phase: PhaseWork}
+	e.load()
+	return e
+}
+
+// Subscribe registers fn to receive every Event.
+func (e *Engine) Subscribe(fn func(Event)) {
+	e.listeners = append(e.listeners, fn)
+}
+
+// SetConfig swaps the cycle setup. A phase that hasn't started yet picks
+// up its new length straight away; a running one keeps its own.
+func (e *Engine) SetConfig(cfg Config) {
+	e.Config = cfg.WithDefaults()
+	if e.Status == StatusStopped {
+		e.load()
+	}
+}
+
+// load sets the current phase up at its full length from the config.
+func (e *Engine) load() {
+	e.length = e.Config.Length(e.Phase)
+	e.Remaining = e.length
+}
+
+// Length of the current phase, as it was when the phase was loaded.
+func (e *Engine) Length() time.Duration {
+	return e.length
+}
+
+// Elapsed is how much of the current phase has run.
+func (e *Engine) Elapsed() time.Duration {
+	return e.Length() - e.Remaining
+}
+
+// Fraction of the current phase still to go, 1 at the start.
+func (e *Engine) Fraction() float64 {
+	if l := e.Length(); l > 0 {
+		return float64(e.Remaining) / float64(l)
+	}
+	return 0
+}
+
+// Start begins the loaded phase, or resumes it if paused.
+func (e *Engine) Start(now time.Time) {
+	switch e.Status {
+	case StatusStopped:
+		e.Status = StatusRunning
+		e.last = now
+		e.emit(EventStarted, now)
+	case StatusPaused:
+		e.Resume(now)
+	}
+}
+
+func (e *Engine) Pause(now time.Time) {
+	if e.Status != StatusRunning {
+		return
+	}
+	e.Update(now)
+	if e.Status != StatusRunning { // Finished on this very update
+		return
+	}
+	e.Status = StatusPaused
+	e.emit(EventPaused, now)
+}
+
+func (e *Engine) Resume(now time.Time) {
+	if e.Status != StatusPaused {
+		return
+	}
+	e.Status = StatusRunning
+	e.last = now
+	e.emit(EventResumed, now)
+}
+
+// Toggle is the one-button control: start, pause or resume.
+func (e *Engine) Toggle(now time.Time) {
+	if e.Status == StatusRunning {
+		e.Pause(now)
+	} else {
+		e.Start(now)
+	}
+}
+
+// Skip ends the current phase early and moves on. Skipped work does not
+// count towards the session counter. A phase that hasn't started, or that
+// ran out just now, is left alone.
+func (e *Engine) Skip(now time.Time) {
+	if e.Status == StatusStopped || e.tick(now) {
+		return
+	}
+	e.emit(EventSkipped, now)
+	e.advance(now, false)
+}
+
+// Reset abandons the cycle: back to a fresh, stopped work phase with the
+// session counter cleared.
+func (e *Engine) Reset(now time.Time) {
+	e.Update(now)
+	if e.Status != StatusStopped {
+		e.emit(EventReset, now)
+	}
+	e.Phase = PhaseWork
+	e.Status = StatusStopped
+	e.Sessions = 0
+	e.load()
+}
+
+// Update counts the running phase down to now and advances the cycle
+// when it runs out.
+func (e *Engine) Update(now time.Time) { e.tick(now) }
+
+// tick is Update, reporting whether a phase ran out. Time past the end of
+// a phase carries over into the next one if it starts by itself, so a
+// long gap between updates (a laptop waking from sleep) can finish
+// several phases, each at the moment it really ended.
+func (e *Engine) tick(now time.Time) bool {
+	if e.Status != StatusRunning {
+		return false
+	}
+	if dt := now.Sub(e.last); dt > 0 {
+		e.Remaining -= dt
+	}
+	e.last = now
+	done := false
+	for e.Status == StatusRunning && e.Remaining <= 0 {
+		over := -e.Remaining
+		end := now.Add(-over)
+		e.Remaining = 0
+		e.emit(EventCompleted, end)
+		e.advance(end, true)
+		if e.Status == StatusRunning {
+			e.Remaining -= over
+			e.last = now
+		}
+		done = true
+	}
+	return done
+}
+
+// next works out which phase follows the current one.
+func (e *Engine) next(completed bool) Phase {
+	if e.Phase.IsBreak() {
+		return PhaseWork
+	}
+	if completed {
+		e.Sessions++
+		if e.Sessions%e.Config.LongBreakEvery == 0 {
+			return PhaseLongBreak
+		}
+	}
+	return PhaseShortBreak
+}
+
+func (e *Engine) advance(now time.Time, completed bool) {
+	e.Phase = e.next(completed)
+	e.load()
+	e.Status = StatusStopped
+	if e.Config.AutoStart {
+		e.Start(now)
+	}
+}
+
+func (e *Engine) emit(kind EventKind, now time.Time) {
+	ev := Event{Kind: kind, Phase: e.Phase, At: now, Length: e.Length(), Elapsed: e.Elapsed()}
+	for _, fn := range e.listeners {
+		fn(ev)
+	}
+}
@@ -0,0 +1,145 @@
+package pomodoro
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+func testConfig(auto bool) Config {
+	return Config{WorkMinutes: 25, ShortBreakMinutes: 5, LongBreakMinutes: 15, LongBreakEvery: 2, AutoStart: auto}
+}
+
+// record collects every event e emits.
+func record(e *Engine) *[]Event {
+	var evs []Event
+	e.Subscribe(func(ev Event) { evs = append(evs, ev) })
+	return &evs
+}
+
+func TestCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		auto     bool
+		after    time.Duration // From the start of the first work phase
+		phase    Phase
+		status   Status
+		left     time.Duration
+		sessions int
+	}{
+		{"mid work", false, 10 * time.Minute, PhaseWork, StatusRunning, 15 * time.Minute, 0},
+		{"work done", false, 25 * time.Minute, PhaseShortBreak, StatusStopped, 5 * time.Minute, 1},
+		{"overshoot without auto-start is dropped", false, 40 * time.Minute, PhaseShortBreak, StatusStopped, 5 * time.Minute, 1},
+		{"overshoot carries into the break", true, 27 * time.Minute, PhaseShortBreak, StatusRunning, 3 * time.Minute, 1},
+		{"a long sleep runs several phases", true, 56 * time.Minute, PhaseLongBreak, StatusRunning, 14 * time.Minute, 2},
+		{"back to work after the long break", true, 81 * time.Minute, PhaseWork, StatusRunning, 14 * time.Minute, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New(testConfig(tt.auto))
+			e.Start(t0)
+			e.Update(t0.Add(tt.after))
+			if e.Phase != tt.phase || e.Status != tt.status || e.Remaining != tt.left || e.Sessions != tt.sessions {
+				t.Errorf("got %v %v %v left, %d sessions; want %v %v %v left, %d sessions",
+					e.Phase, e.Status, e.Remaining, e.Sessions, tt.phase, tt.status, tt.left, tt.sessions)
+			}
+		})
+	}
+}
+
+func TestCompletedAtTheRealEnd(t *testing.T) {
+	e := New(testConfig(true))
+	evs := record(e)
+	e.Start(t0)
+	e.Update(t0.Add(time.Hour))
+	var ends []time.Duration
+	for _, ev := range *evs {
+		if ev.Kind == EventCompleted {
+			ends = append(ends, ev.At.Sub(t0))
+			if ev.Elapsed != ev.Length {
+				t.Errorf("%v completed with %v of %v elapsed", ev.Phase, ev.Elapsed, ev.Length)
+			}
+		}
+	}
+	want := []time.Duration{25 * time.Minute, 30 * time.Minute, 55 * time.Minute}
+	if !slices.Equal(ends, want) {
+		t.Errorf("completions at %v, want %v", ends, want)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	e := New(testConfig(false))
+	e.Start(t0)
+	e.Toggle(t0.Add(5 * time.Minute))
+	if e.Status != StatusPaused {
+		t.Fatalf("status %v after Toggle, want paused", e.Status)
+	}
+	e.Update(t0.Add(time.Hour)) // Paused time doesn't count
+	e.Toggle(t0.Add(time.Hour))
+	e.Update(t0.Add(time.Hour + 5*time.Minute))
+	if e.Elapsed() != 10*time.Minute {
+		t.Errorf("elapsed %v, want 10m", e.Elapsed())
+	}
+}
+
+func TestSetConfigMidPhase(t *testing.T) {
+	e := New(testConfig(false))
+	e.Start(t0)
+	e.Update(t0.Add(20 * time.Minute))
+	cfg := testConfig(false)
+	cfg.WorkMinutes = 10
+	e.SetConfig(cfg)
+	if e.Length() != 25*time.Minute || e.Elapsed() != 20*time.Minute || e.Fraction() != 0.2 {
+		t.Errorf("running phase changed: length %v, elapsed %v, fraction %v", e.Length(), e.Elapsed(), e.Fraction())
+	}
+	e.Reset(t0.Add(21 * time.Minute))
+	if e.Length() != 10*time.Minute || e.Remaining != 10*time.Minute {
+		t.Errorf("next phase didn't pick up the new length: %v, %v left", e.Length(), e.Remaining)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	tests := []struct {
+		name   string
+		start  bool
+		at     time.Duration
+		kinds  []EventKind
+		phase  Phase
+		status Status
+	}{
+		{"running work", true, 10 * time.Minute, []EventKind{EventStarted, EventSkipped}, PhaseShortBreak, StatusStopped},
+		{"stopped", false, 0, nil, PhaseWork, StatusStopped},
+		{"just ran out", true, 26 * time.Minute, []EventKind{EventStarted, EventCompleted}, PhaseShortBreak, StatusStopped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New(testConfig(false))
+			evs := record(e)
+			if tt.start {
+				e.Start(t0)
+			}
+			e.Skip(t0.Add(tt.at))
+			var kinds []EventKind
+			for _, ev := range *evs {
+				kinds = append(kinds, ev.Kind)
+			}
+			if !slices.Equal(kinds, tt.kinds) {
+				t.Fatalf("events %v, want %v", kinds, tt.kinds)
+			}
+			if e.Phase != tt.phase || e.Status != tt.status || e.Sessions > 1 {
+				t.Errorf("got %v %v (%d sessions), want %v %v", e.Phase, e.Status, e.Sessions, tt.phase, tt.status)
+			}
+		})
+	}
+}
+
+func TestSkippedWorkDoesNotCount(t *testing.T) {
+	e := New(testConfig(true))
+	e.Start(t0)
+	e.Skip(t0.Add(time.Minute))
+	if e.Sessions != 0 || e.Phase != PhaseShortBreak {
+		t.Errorf("got %v with %d sessions, want short_break with 0", e.Phase, e.Sessions)
+	}
+}
@@ -0,0 +1,226 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// VersionKey is the top-level JSON field holding the schema version.
+// Files written before versioning existed have none and count as 0.
+const VersionKey = "version"
+
+// ErrNotFound is returned by Load when neither the file nor any backup
+// exists, i.e. on first launch.
+var ErrNotFound = errors.New("save: no save file")
+
+// Migration upgrades a decoded document by one schema version in place.
+type Migration func(doc map[string]any) error
+
+// File is one versioned JSON save file. Writes go to a temp file that is
+// renamed over the original, so a crash never leaves a half-written save.
+// The first Save of a session keeps the file as it was as Path.bak.1,
+// moving older backups up to Path.bak.<Backups>; later saves in the same
+// session just replace the file, so the backups reach back a session each
+// rather than a few saves.
+type File struct {
+	Path    string
+	Version int // Schema version written by Save
+
+	// Migrations[n] upgrades a version n document to n+1.
+	Migrations map[int]Migration
+
+	Backups int
+
+	rotated bool // This session's backup is taken
+}
+
+// Result says where Load found its data.
+type Result struct {
+	Source      string // Path actually read
+	Recovered   bool   // The primary file was unreadable; a backup was used
+	FromVersion int    // Version before migrations
+}
+
+// Load decodes the newest readable save into v, migrating it to Version.
+// If the primary file is corrupt it is kept aside as Path.corrupt and the
+// newest valid backup is used instead. v, a pointer, is only written once
+// a file has decoded completely.
+func (f *File) Load(v any) (Result, error) { return f.load(v, true) }
+
+// Read is Load for callers that only look: it never writes anything, not
+// even the Path.corrupt copy of a broken file.
+func (f *File) Read(v any) (Result, error) { return f.load(v, false) }
+
+func (f *File) load(v any, keepCorrupt bool) (Result, error) {
+	from, err := f.loadFrom(f.Path, v)
+	if err == nil {
+		return Result{Source: f.Path, FromVersion: from}, nil
+	}
+	primaryErr := err
+	if keepCorrupt && !errors.Is(err, fs.ErrNotExist) {
+		if cerr := copyFile(f.Path, f.Path+".corrupt"); cerr != nil {
+			primaryErr = errors.Join(err, cerr)
+		}
+	}
+
+	for i := 1; i <= f.Backups; i++ {
+		p := f.backupPath(i)
+		if from, err := f.loadFrom(p, v); err == nil {
+			return Result{Source: p, Recovered: true, FromVersion: from}, nil
+		}
+	}
+	if errors.Is(primaryErr, fs.ErrNotExist) {
+		return Result{}, ErrNotFound
+	}
+	return Result{}, fmt.Errorf("save: %s unreadable and no valid backup: %w", f.Path, primaryErr)
+}
+
+func (f *File) loadFrom(path string, v any) (int, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(d, &doc); err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	from := 0
+	if raw, ok := doc[VersionKey]; ok {
+		n, ok := raw.(float64)
+		if !ok {
+			return 0, fmt.Errorf("%s: bad %q field", path, VersionKey)
+		}
+		from = int(n)
+	}
+	if from > f.Version {
+		return 0, fmt.Errorf("%s: version %d is newer than supported %d", path, from, f.Version)
+	}
+	for ver := from; ver < f.Version; ver++ {
+		if m := f.Migrations[ver]; m != nil {
+			if err := m(doc); err != nil {
+				return 0, fmt.Errorf("%s: migrating from version %d: %w", path, ver, err)
+			}
+		}
+	}
+	delete(doc, VersionKey)
+
+	d, err = json.Marshal(doc)
+	if err != nil {
+		return 0, err
+	}
+	// Decode into a fresh value so a type mismatch half way through
+	// leaves nothing behind in v for the next candidate to build on
+	fresh := reflect.New(reflect.TypeOf(v).Elem())
+	if err := json.Unmarshal(d, fresh.Interface()); err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	reflect.ValueOf(v).Elem().Set(fresh.Elem())
+	return from, nil
+}
+
+// Save atomically replaces the file with v (which must encode to a JSON
+// object), stamped with the current Version. The first save of a session
+// rotates the old file into the backups first.
+func (f *File) Save(v any) error {
+	d, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(d, &doc); err != nil {
+		return fmt.Errorf("save: %T is not a JSON object: %w", v, err)
+	}
+	doc[VersionKey] = f.Version
+	if d, err = json.MarshalIndent(doc, "", " "); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(f.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.Write(d); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := f.rotate(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// rotate shifts Path.bak.1..N-1 up by one and copies the current file to
+// Path.bak.1, once per session. The primary stays in place until the
+// rename replaces it.
+func (f *File) rotate() error {
+	if f.Backups <= 0 || f.rotated {
+		return nil
+	}
+	if _, err := os.Stat(f.Path); errors.Is(err, fs.ErrNotExist) {
+		f.rotated = true // Nothing to keep yet
+		return nil
+	}
+	for i := f.Backups - 1; i >= 1; i-- {
+		err := os.Rename(f.backupPath(i), f.backupPath(i+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err := copyFile(f.Path, f.backupPath(1)); err != nil {
+		return err
+	}
+	f.rotated = true
+	return nil
+}
+
+func (f *File) backupPath(i int) string {
+	return fmt.Sprintf("%s.bak.%d", f.Path, i)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// syncDir flushes the rename to disk where the platform allows it.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
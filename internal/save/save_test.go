@@ -0,0 +1,169 @@
+package save
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type doc struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Extra string `json:"extra,omitempty"`
+}
+
+func newFile(t *testing.T) *File {
+	return &File{Path: filepath.Join(t.TempDir(), "stats.json"), Version: 2, Backups: 3}
+}
+
+func write(t *testing.T, path, s string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(s), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := newFile(t)
+	if _, err := f.Load(&doc{}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("first launch: got %v, want ErrNotFound", err)
+	}
+	if err := f.Save(doc{Name: "panda", Count: 3}); err != nil {
+		t.Fatal(err)
+	}
+	var got doc
+	res, err := f.Load(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != (doc{Name: "panda", Count: 3}) || res.Recovered || res.FromVersion != 2 {
+		t.Errorf("got %+v from %+v", got, res)
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		backups []string // .bak.1 onwards; "" for none
+		want    doc
+		source  string // Suffix of the path read
+		fails   bool
+	}{
+		{"truncated primary", `{"name": "pan`, []string{`{"version": 2, "name": "bak1", "count": 1}`}, doc{Name: "bak1", Count: 1}, ".bak.1", false},
+		{"bad first backup too", `{`, []string{`nope`, `{"version": 2, "name": "bak2"}`}, doc{Name: "bak2"}, ".bak.2", false},
+		{"type mismatch leaves nothing behind", `{"version": 2, "name": "bad", "extra": "stale", "count": "three"}`,
+			[]string{`{"version": 2, "name": "bak1", "count": 1}`}, doc{Name: "bak1", Count: 1}, ".bak.1", false},
+		{"newer than supported", `{"version": 9, "name": "future"}`, []string{`{"version": 2, "name": "bak1"}`}, doc{Name: "bak1"}, ".bak.1", false},
+		{"nothing readable", `{`, []string{"", `}`}, doc{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFile(t)
+			write(t, f.Path, tt.primary)
+			for i, b := range tt.backups {
+				if b != "" {
+					write(t, f.backupPath(i+1), b)
+				}
+			}
+			var got doc
+			res, err := f.Load(&got)
+			if tt.fails {
+				if err == nil || errors.Is(err, ErrNotFound) {
+					t.Fatalf("got %+v, %v; want an error", got, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || res.Recovered == tt.fails || filepath.Ext(res.Source) != filepath.Ext(tt.source) {
+				t.Errorf("got %+v from %+v, want %+v from %s", got, res, tt.want, tt.source)
+			}
+			// The broken primary is kept for a look later
+			if kept, err := os.ReadFile(f.Path + ".corrupt"); err != nil || string(kept) != tt.primary {
+				t.Errorf("%s.corrupt = %q, %v", f.Path, kept, err)
+			}
+		})
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	f := newFile(t)
+	f.Migrations = map[int]Migration{
+		0: func(d map[string]any) error { d["name"] = d["title"]; delete(d, "title"); return nil },
+		1: func(d map[string]any) error { d["count"] = d["count"].(float64) * 10; return nil },
+	}
+	tests := []struct {
+		name, file string
+		want       doc
+		from       int
+	}{
+		{"unversioned", `{"title": "old", "count": 2}`, doc{Name: "old", Count: 20}, 0},
+		{"v1", `{"version": 1, "name": "mid", "count": 2}`, doc{Name: "mid", Count: 20}, 1},
+		{"current", `{"version": 2, "name": "new", "count": 2}`, doc{Name: "new", Count: 2}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write(t, f.Path, tt.file)
+			var got doc
+			res, err := f.Load(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || res.FromVersion != tt.from {
+				t.Errorf("got %+v from version %d, want %+v from %d", got, res.FromVersion, tt.want, tt.from)
+			}
+		})
+	}
+
+	f.Migrations[1] = func(map[string]any) error { return errors.New("no") }
+	write(t, f.Path, `{"version": 1}`)
+	if _, err := f.Load(&doc{}); err == nil {
+		t.Error("a failed migration loaded anyway")
+	}
+}
+
+func TestBackupsPerSession(t *testing.T) {
+	f := newFile(t)
+	for _, n := range []int{1, 2, 3} { // One session's saves
+		if err := f.Save(doc{Count: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(f.backupPath(1)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("first session made a backup: %v", err)
+	}
+	for session := 2; session <= 5; session++ {
+		f = &File{Path: f.Path, Version: f.Version, Backups: f.Backups}
+		for _, n := range []int{1, 2, 3} {
+			if err := f.Save(doc{Count: session*10 + n}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Each backup is where a session left off
+	for i, want := range []int{43, 33, 23} {
+		var got doc
+		if _, err := f.loadFrom(f.backupPath(i+1), &got); err != nil || got.Count != want {
+			t.Errorf("bak.%d: got %+v, %v; want count %d", i+1, got, err, want)
+		}
+	}
+	if _, err := os.Stat(f.backupPath(4)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("kept more than %d backups", f.Backups)
+	}
+}
+
+func TestReadLeavesFilesAlone(t *testing.T) {
+	f := newFile(t)
+	write(t, f.Path, `{"name": "pan`)
+	write(t, f.backupPath(1), `{"version": 2, "name": "bak1"}`)
+	var got doc
+	res, err := f.Read(&got)
+	if err != nil || got.Name != "bak1" || !res.Recovered {
+		t.Fatalf("got %+v from %+v, %v; want the backup", got, res, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(f.Path))
+	if len(entries) != 2 {
+		t.Errorf("Read wrote files: %v", entries)
+	}
+}
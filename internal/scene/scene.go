@@ -0,0 +1,101 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ID identifies a registered scene (Directory, Focus, Fishing, ...).
+type ID int
+
+// Scene is one screen of the app. The manager calls Enter when the scene
+// becomes active and Exit when it is replaced; Update/Draw/Layout mirror
+// ebiten.Game and are only called on the active scene.
+type Scene interface {
+	Enter()
+	Exit()
+	Update() error
+	Draw(screen *ebiten.Image)
+	Layout(outsideWidth, outsideHeight int) (int, int)
+}
+
+// Manager owns the registered scenes and the transitions between them.
+// It implements ebiten.Game by delegating to the active scene.
+type Manager struct {
+	scenes  map[ID]Scene
+	current ID
+	active  Scene
+
+	pending    ID
+	hasPending bool
+}
+
+func NewManager() *Manager {
+	return &Manager{scenes: map[ID]Scene{}}
+}
+
+// Register adds a scene under id, replacing any previous one.
+func (m *Manager) Register(id ID, s Scene) {
+	m.scenes[id] = s
+}
+
+// Scene returns the scene registered under id, or nil.
+func (m *Manager) Scene(id ID) Scene {
+	return m.scenes[id]
+}
+
+// Current returns the ID of the active scene.
+func (m *Manager) Current() ID {
+	return m.current
+}
+
+// Switch requests a transition to id. The switch happens at the start of
+// the next Update so a scene never gets swapped out halfway through its
+// own frame. With no active scene yet, it happens immediately.
+func (m *Manager) Switch(id ID) {
+	if m.active == nil {
+		m.enter(id)
+		return
+	}
+	m.pending = id
+	m.hasPending = true
+}
+
+func (m *Manager) enter(id ID) {
+	s, ok := m.scenes[id]
+	if !ok {
+		panic(fmt.Sprintf("scene: no scene registered for id %d", id))
+	}
+	if m.active != nil {
+		m.active.Exit()
+	}
+	m.current = id
+	m.active = s
+	s.Enter()
+}
+
+func (m *Manager) Update() error {
+	if m.hasPending {
+		m.hasPending = false
+		m.enter(m.pending)
+	}
+	if m.active == nil {
+		return nil
+	}
+	return m.active.Update()
+}
+
+func (m *Manager) Draw(screen *ebiten.Image) {
+	if m.active == nil {
+		return
+	}
+	m.active.Draw(screen)
+}
+
+func (m *Manager) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if m.active == nil {
+		return outsideWidth, outsideHeight
+	}
+	return m.active.Layout(outsideWidth, outsideHeight)
+}
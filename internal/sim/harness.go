@@ -0,0 +1,146 @@
+// Package sim runs the app's scenes headless for deterministic tests:
+// keys are scripted per tick, the clock only moves with the simulation and
+// randomness comes from a fixed seed. Nothing here opens a window, though
+// importing ebiten still needs a display on Linux (use xvfb-run in CI).
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/clock"
+	"panda/internal/gamemode"
+	"panda/internal/input"
+	"panda/internal/scene"
+)
+
+// TickDuration is how far the clock moves per simulated tick (60 TPS).
+const TickDuration = time.Second / 60
+
+// Epoch is the simulated start time: a fixed date so day-based stats
+// are reproducible.
+var Epoch = time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+type Harness struct {
+	Ctx   *gamemode.Context
+	Keys  *input.Script
+	Clock *clock.Manual
+}
+
+// New builds a context with default settings, registers every scene and
+// leaves the directory active.
+func New(seed int64) *Harness {
+	h := &Harness{
+		Keys:  input.NewScript(),
+		Clock: clock.NewManual(Epoch),
+	}
+	h.Ctx = gamemode.NewContext(h.Keys, h.Clock, rand.New(rand.NewSource(seed)))
+	h.Ctx.Stats.LastLoginDate = Epoch.Format("2006-01-02")
+	h.Ctx.ApplySettings()
+	h.Ctx.RegisterScenes()
+	return h
+}
+
+// Step advances the clock by one tick and runs one Update.
+func (h *Harness) Step() error {
+	h.Clock.Advance(TickDuration)
+	return h.Ctx.Update()
+}
+
+// Run steps n ticks, stopping at the first error.
+func (h *Harness) Run(n int) error {
+	for i := 0; i < n; i++ {
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunUntil steps until cond holds, failing after max ticks.
+func (h *Harness) RunUntil(cond func() bool, max int) error {
+	for i := 0; i < max; i++ {
+		if cond() {
+			return nil
+		}
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+	if cond() {
+		return nil
+	}
+	return fmt.Errorf("sim: condition not met after %d ticks", max)
+}
+
+// Tap presses keys for exactly one tick, then releases them.
+func (h *Harness) Tap(keys ...ebiten.Key) error {
+	return h.Hold(1, keys...)
+}
+
+// Hold keeps keys down for n ticks, then releases them.
+func (h *Harness) Hold(n int, keys ...ebiten.Key) error {
+	h.Keys.Press(keys...)
+	defer h.Keys.Release(keys...)
+	return h.Run(n)
+}
+
+// Click presses and releases the mouse at logical x, y, one tick each.
+func (h *Harness) Click(x, y int) error {
+	h.Keys.MoveTo(x, y)
+	h.Keys.PressButton(ebiten.MouseButtonLeft)
+	if err := h.Step(); err != nil {
+		return err
+	}
+	h.Keys.ReleaseButton(ebiten.MouseButtonLeft)
+	return h.Step()
+}
+
+// Swipe drags a finger from x, y by dx, dy over n ticks and lifts it.
+func (h *Harness) Swipe(x, y, dx, dy, n int) error {
+	const finger = 1
+	for i := 0; i <= n; i++ {
+		h.Keys.Touch(finger, x+dx*i/n, y+dy*i/n)
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+	h.Keys.Lift(finger)
+	return h.Step()
+}
+
+// Advance jumps the clock forward without running any ticks; the next
+// Step sees the whole gap, as after a long frame hitch.
+func (h *Harness) Advance(d time.Duration) {
+	h.Clock.Advance(d)
+}
+
+// Switch moves to scene id, stepping once so the transition applies.
+func (h *Harness) Switch(id scene.ID) error {
+	h.Ctx.Scenes.Switch(id)
+	return h.Step()
+}
+
+// Scene returns the active scene ID.
+func (h *Harness) Scene() scene.ID {
+	return h.Ctx.Scenes.Current()
+}
+
+func (h *Harness) Focus() *gamemode.FocusMode {
+	return h.Ctx.Scenes.Scene(gamemode.ModeFocus).(*gamemode.FocusMode)
+}
+
+func (h *Harness) Fishing() *gamemode.FishingMode {
+	return h.Ctx.Scenes.Scene(gamemode.ModeFishing).(*gamemode.FishingMode)
+}
+
+func (h *Harness) Pacman() *gamemode.PacmanMode {
+	return h.Ctx.Scenes.Scene(gamemode.ModePacman).(*gamemode.PacmanMode)
+}
+
+func (h *Harness) Endless() *gamemode.PacmanMode {
+	return h.Ctx.Scenes.Scene(gamemode.ModeEndless).(*gamemode.PacmanMode)
+}
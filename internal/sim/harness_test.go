@@ -0,0 +1,141 @@
+package sim
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/gamemode"
+	"panda/internal/maze"
+	"panda/internal/pomodoro"
+)
+
+func TestFocusCycle(t *testing.T) {
+	h := New(1)
+	if err := h.Switch(gamemode.ModeFocus); err != nil {
+		t.Fatal(err)
+	}
+	f, timer := h.Focus(), h.Ctx.Timer
+	if err := h.Tap(ebiten.KeySpace); err != nil {
+		t.Fatal(err)
+	}
+	if timer.Status != pomodoro.StatusRunning || f.State() != gamemode.FocusRunning {
+		t.Fatalf("SPACE left the timer %v, focus %v", timer.Status, f.State())
+	}
+
+	// Most of the session passes in one hitch; the gopher turns up near the end
+	h.Advance(timer.Remaining - time.Minute)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if f.Gopher != gamemode.GopherNear {
+		t.Errorf("a minute left: gopher %v, want near", f.Gopher)
+	}
+	h.Advance(time.Minute)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if timer.Phase != pomodoro.PhaseShortBreak || timer.Sessions != 1 || f.State() != gamemode.FocusBreak {
+		t.Fatalf("after the session: %v with %d sessions, focus %v", timer.Phase, timer.Sessions, f.State())
+	}
+
+	// Take the break and come back to work
+	if err := h.Tap(ebiten.KeySpace); err != nil {
+		t.Fatal(err)
+	}
+	h.Advance(timer.Length())
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if timer.Phase != pomodoro.PhaseWork || timer.Status != pomodoro.StatusStopped || f.State() != gamemode.FocusIdle {
+		t.Errorf("after the break: %v %v, focus %v; want work, stopped", timer.Phase, timer.Status, f.State())
+	}
+}
+
+func TestPacmanClearLevel(t *testing.T) {
+	h := New(1)
+	if err := h.Switch(gamemode.ModePacman); err != nil {
+		t.Fatal(err)
+	}
+	// A corridor with the dots ahead and a gopher far behind
+	l, err := maze.Parse("corridor.txt", strings.NewReader(strings.Join([]string{
+		"###############",
+		"#G      P.....#",
+		"###############",
+	}, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := h.Pacman()
+	p.Levels = []*maze.Level{l}
+	p.Reset()
+
+	if err := h.RunUntil(func() bool { return p.Ready == 0 }, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Tap(ebiten.KeyArrowRight); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RunUntil(func() bool { return p.Win || p.GameOver }, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Win || p.Breakdown.Dots != 5 || !p.Finished() || h.Ctx.Stats.PacmanWinsToday != 1 {
+		t.Errorf("win %v, %d dots eaten, finished %v, %d wins today; want a cleared board",
+			p.Win, p.Breakdown.Dots, p.Finished(), h.Ctx.Stats.PacmanWinsToday)
+	}
+}
+
+func TestPacmanScoring(t *testing.T) {
+	h := New(1)
+	if err := h.Switch(gamemode.ModePacman); err != nil {
+		t.Fatal(err)
+	}
+	// A pellet just ahead of the panda and both gophers beyond it
+	l, err := maze.Parse("pellet.txt", strings.NewReader(strings.Join([]string{
+		"################",
+		"#P.o..GG.......#",
+		"################",
+	}, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := h.Pacman()
+	p.Levels = []*maze.Level{l}
+	p.Reset()
+	dots := l.Dots()
+
+	if err := h.RunUntil(func() bool { return p.Ready == 0 }, 1000); err != nil {
+		t.Fatal(err)
+	}
+	h.Keys.Press(ebiten.KeyArrowRight)
+	var ghostPoints, fruitAt []int
+	for i := 0; i < 1000 && !p.Win && !p.GameOver; i++ {
+		eaten, fruit := p.Breakdown.Ghosts, p.FruitTicks
+		if err := h.Step(); err != nil {
+			t.Fatal(err)
+		}
+		h.Keys.Release(ebiten.KeyArrowRight)
+		if p.Breakdown.Ghosts > eaten {
+			ghostPoints = append(ghostPoints, p.Breakdown.GhostPoints)
+		}
+		if p.FruitTicks > fruit+1 {
+			fruitAt = append(fruitAt, dots-p.Maze.Dots())
+		}
+	}
+	if !p.Win {
+		t.Fatalf("board not cleared: game over %v, %d dots left", p.GameOver, p.Maze.Dots())
+	}
+	if !slices.Equal(ghostPoints, []int{200, 600}) {
+		t.Errorf("ghost points %v after each gopher, want 200 then 200+400", ghostPoints)
+	}
+	if !slices.Equal(fruitAt, []int{dots / 3, dots * 2 / 3}) {
+		t.Errorf("fruit came out after %v of %d dots, want a third and two thirds", fruitAt, dots)
+	}
+	b := p.Breakdown
+	if b.Pellets != 1 || b.Dots != dots-1 || b.LivesBonus != p.Lives*500 || b.Total() != p.Score {
+		t.Errorf("breakdown %+v totals %d, score %d", b, b.Total(), p.Score)
+	}
+}
@@ -0,0 +1,252 @@
+package sprites
+
+import "image/color"
+
+// --- Palette ---
+var (
+	ColGopherBlue  = color.RGBA{0x7f, 0xd5, 0xea, 0xff} // Cyan
+	ColGopherDark  = color.RGBA{0x00, 0x00, 0x00, 0xff} // Black
+	ColGopherSnout = color.RGBA{0xfd, 0xe6, 0x8a, 0xff} // Tan
+	ColGopherTooth = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	ColGopherScary = color.RGBA{0x21, 0x21, 0xde, 0xff} // Frightened blue
+
+	ColPandaDark  = color.RGBA{20, 20, 20, 255}
+	ColPandaWhite = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	ColHeart      = color.RGBA{0xff, 0x6b, 0x6b, 0xff} // Red
+
+	// Keyboard Colors
+	ColDesk     = color.RGBA{0x8b, 0x5a, 0x2b, 0xff} // Wood
+	ColKeyBase  = color.RGBA{0x20, 0x20, 0x20, 0xff} // Chassis
+	ColKeyRow1  = color.RGBA{0x40, 0x40, 0x40, 0xff} // Dark Keys
+	ColKeyRow2  = color.RGBA{0x80, 0x80, 0x80, 0xff} // Light Keys
+	ColKeySpace = color.RGBA{0xAA, 0xAA, 0xAA, 0xff} // Spacebar
+
+	ColRod = color.RGBA{139, 69, 19, 255}
+
+	ColBamboo     = color.RGBA{0x5a, 0xb0, 0x3c, 0xff}
+	ColBambooDark = color.RGBA{0x2f, 0x6b, 0x1f, 0xff} // Joints
+)
+
+// --- Panda ---
+
+// Panda is the full-body panda without arms or legs; dress it with
+// PandaLimbs or a costume. The origin is the centre of the head.
+var Panda = &Sprite{
+	Name: "panda",
+	Layers: []Layer{
+		{Name: "ears", Shapes: []Shape{
+			C(-12, -15, 8, ColPandaDark),
+			C(12, -15, 8, ColPandaDark),
+		}},
+		{Name: "head", Shapes: []Shape{
+			C(0, 0, 20, ColPandaWhite),
+		}},
+		{Name: "face", Shapes: []Shape{
+			C(-8, -2, 6, ColPandaDark), // Eye patches
+			C(8, -2, 6, ColPandaDark),
+			C(-8, -3, 2, ColPandaWhite), // Eyes
+			C(8, -3, 2, ColPandaWhite),
+			C(0, 5, 3, ColPandaDark), // Nose
+		}},
+		{Name: "body", Shapes: []Shape{
+			R(-15, 15, 30, 25, ColPandaWhite),
+		}},
+	},
+	Anchors: map[string]Point{
+		"arm_l":  {-18, 20},
+		"arm_r":  {18, 20},
+		"foot_l": {-12, 40},
+		"foot_r": {12, 40},
+		"hand_r": {15, 20}, // Where a held prop starts
+		"lap":    {0, 25},  // Top centre of a desk in front
+	},
+}
+
+// PandaHead is the small panda head used as the Panda-Man player, 8px
+// in radius.
+var PandaHead = &Sprite{
+	Name: "panda-head",
+	Layers: []Layer{
+		{Name: "ears", Shapes: []Shape{
+			C(-4, -5, 4, ColPandaDark),
+			C(4, -5, 4, ColPandaDark),
+		}},
+		{Name: "head", Shapes: []Shape{
+			C(0, 0, 8, ColPandaWhite),
+			C(-3, -1, 2, ColPandaDark),
+			C(3, -1, 2, ColPandaDark),
+		}},
+	},
+}
+
+// --- Panda Costumes ---
+
+// PandaArms and PandaFeet are the plain limbs; PandaLimbs is both.
+var (
+	PandaArms = Layer{Name: "arms", Shapes: []Shape{
+		C(-18, 20, 7, ColPandaDark),
+		C(18, 20, 7, ColPandaDark),
+	}}
+	PandaFeet = Layer{Name: "feet", Shapes: []Shape{
+		C(-12, 40, 7, ColPandaDark),
+		C(12, 40, 7, ColPandaDark),
+	}}
+)
+
+var PandaLimbs = []Layer{PandaArms, PandaFeet}
+
+// FishingRod is held in the right hand.
+var FishingRod = Layer{Name: "rod", Anchor: "hand_r", Shapes: []Shape{
+	L(0, 0, 25, -30, 2, ColRod),
+}}
+
+// Desk is a wooden desk with a keyboard, in front of the lap.
+var Desk = Layer{Name: "desk", Anchor: "lap", Shapes: deskShapes()}
+
+func deskShapes() []Shape {
+	s := []Shape{
+		R(-40, 0, 80, 20, ColDesk),
+		R(-25, 0, 50, 15, ColKeyBase), // Chassis
+	}
+	for i := 0; i < 10; i++ { // Row 1 (Numbers - Dark)
+		s = append(s, R(-24+float32(i*5), 1, 4, 3, ColKeyRow1))
+	}
+	for i := 0; i < 9; i++ { // Rows 2 and 3 (Letters, Home)
+		s = append(s, R(-22+float32(i*5), 5, 4, 3, ColKeyRow2))
+		s = append(s, R(-22+float32(i*5), 9, 4, 3, ColKeyRow2))
+	}
+	return append(s, R(-10, 13, 20, 2, ColKeySpace)) // Spacebar
+}
+
+// TypingPaws rests the paws on the Desk keyboard; up lifts the left
+// and drops the right for the typing animation.
+func TypingPaws(up bool) Layer {
+	var off float32
+	if up {
+		off = -3
+	}
+	return Layer{Name: "paws", Anchor: "lap", Shapes: []Shape{
+		C(-15, 5+off, 6, ColPandaDark),
+		C(15, 5-off, 6, ColPandaDark),
+	}}
+}
+
+// --- Gopher ---
+
+// Gopher is the full-body gopher; the origin is between the eyes and the
+// snout.
+var Gopher = &Sprite{
+	Name: "gopher",
+	Layers: []Layer{
+		{Name: "body", Shapes: []Shape{
+			C(0, 15, 18, ColGopherBlue),        // Bot
+			C(-5, -10, 16, ColGopherBlue),      // Top
+			R(-20, -10, 35, 25, ColGopherBlue), // Mid
+		}},
+		{Name: "eyes", Shapes: []Shape{
+			C(-12, -12, 7, ColGopherTooth),
+			C(-10, -12, 2, ColGopherDark),
+			C(2, -12, 7, ColGopherTooth),
+			C(4, -12, 2, ColGopherDark),
+		}},
+		{Name: "snout", Shapes: []Shape{
+			R(-10, -2, 14, 8, ColGopherSnout),
+			C(-10, 2, 4, ColGopherSnout),
+			C(4, 2, 4, ColGopherSnout),
+			C(-3, -1, 3, ColGopherDark),
+			R(-5, 4, 4, 5, ColGopherTooth), // Tooth
+		}},
+		{Name: "ears", Shapes: []Shape{
+			C(-18, -18, 4, ColGopherBlue),
+			C(8, -20, 4, ColGopherBlue),
+		}},
+	},
+}
+
+// GopherHead is the small gopher head used as the Panda-Man ghost.
+var GopherHead = &Sprite{
+	Name: "gopher-head",
+	Layers: []Layer{
+		{Name: "head", Shapes: []Shape{
+			C(0, 0, 7, ColGopherBlue),
+			C(-6, -5, 2, ColGopherBlue),
+			C(6, -5, 2, ColGopherBlue),
+		}},
+		{Name: "face", Shapes: []Shape{
+			C(-3, -2, 3, ColGopherTooth),
+			C(3, -2, 3, ColGopherTooth),
+			C(-3, -2, 1, ColGopherDark),
+			C(3, -2, 1, ColGopherDark),
+			C(0, 2, 3, ColGopherSnout),
+			C(0, 1, 1, ColGopherDark),
+			R(-1, 3, 2, 2, ColGopherTooth),
+		}},
+	},
+}
+
+// GopherScared and GopherFlash are a frightened ghost, blue and then
+// blinking white as the fright wears off.
+var (
+	GopherScared = scaredGopher("gopher-scared", ColGopherScary, ColGopherSnout)
+	GopherFlash  = scaredGopher("gopher-flash", ColGopherTooth, ColHeart)
+)
+
+func scaredGopher(name string, body, face color.RGBA) *Sprite {
+	return &Sprite{
+		Name: name,
+		Layers: []Layer{
+			{Name: "head", Shapes: []Shape{
+				C(0, 0, 7, body),
+				C(-6, -5, 2, body),
+				C(6, -5, 2, body),
+			}},
+			{Name: "face", Shapes: []Shape{
+				C(-3, -2, 1, face),
+				C(3, -2, 1, face),
+				L(-4, 3, -2, 2, 1, face),
+				L(-2, 2, 0, 3, 1, face),
+				L(0, 3, 2, 2, 1, face),
+				L(2, 2, 4, 3, 1, face),
+			}},
+		},
+	}
+}
+
+// GopherEyes is an eaten ghost on its way home.
+var GopherEyes = &Sprite{
+	Name: "gopher-eyes",
+	Layers: []Layer{
+		{Name: "eyes", Shapes: []Shape{
+			C(-3, -2, 3, ColGopherTooth),
+			C(3, -2, 3, ColGopherTooth),
+			C(-3, -2, 1, ColGopherDark),
+			C(3, -2, 1, ColGopherDark),
+		}},
+	},
+}
+
+// --- Props ---
+
+// Bamboo is Panda-Man's bonus fruit: a short jointed stalk with a leaf.
+var Bamboo = &Sprite{
+	Name: "bamboo",
+	Layers: []Layer{
+		{Name: "stalk", Shapes: []Shape{
+			R(-2, -7, 4, 14, ColBamboo),
+			R(-2, -2, 4, 1, ColBambooDark),
+			R(-2, 3, 4, 1, ColBambooDark),
+			L(1, -4, 6, -7, 2, ColBamboo),
+		}},
+	},
+}
+
+var Heart = &Sprite{
+	Name: "heart",
+	Layers: []Layer{
+		{Name: "heart", Shapes: []Shape{
+			C(-3, 0, 3, ColHeart),
+			C(3, 0, 3, ColHeart),
+			C(0, 4, 3, ColHeart),
+		}},
+	},
+}
@@ -0,0 +1,194 @@
+// Package sprites draws the app's procedural characters. A character is
+// data: layers of circles, rectangles and lines around an origin, plus
+// named anchor points. Costumes and props are more layers placed
+// relative to those anchors, so they compose with any character that
+// has the anchors they need. A sprite is rendered once per scale into a
+// cached image and can be drawn flipped.
+package sprites
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Kind is a shape primitive.
+type Kind int
+
+const (
+	Circle Kind = iota // Centre X,Y, radius R
+	Rect               // Top-left X,Y, size W,H
+	Line               // From X,Y to X2,Y2, Width wide
+)
+
+// Shape is one filled primitive, in the coordinates of its layer.
+type Shape struct {
+	Kind  Kind
+	X, Y  float32
+	R     float32
+	W, H  float32
+	X2    float32
+	Y2    float32
+	Width float32
+	Color color.RGBA
+}
+
+// Shorthands for writing shape tables.
+
+func C(x, y, r float32, c color.RGBA) Shape {
+	return Shape{Kind: Circle, X: x, Y: y, R: r, Color: c}
+}
+
+func R(x, y, w, h float32, c color.RGBA) Shape {
+	return Shape{Kind: Rect, X: x, Y: y, W: w, H: h, Color: c}
+}
+
+func L(x, y, x2, y2, width float32, c color.RGBA) Shape {
+	return Shape{Kind: Line, X: x, Y: y, X2: x2, Y2: y2, Width: width, Color: c}
+}
+
+// Point is a position relative to a sprite's origin.
+type Point struct{ X, Y float32 }
+
+// Layer is a group of shapes drawn together. Shapes are offset by the
+// named Anchor of the sprite they're added to (none: the origin). Layers
+// draw in ascending Z; equal Z keeps the order they were added in.
+type Layer struct {
+	Name   string
+	Anchor string
+	Z      int
+	Shapes []Shape
+}
+
+// Sprite is a character or prop: layers plus the anchors costumes hang
+// from. Treat it as immutable once built; With makes variants.
+type Sprite struct {
+	Name    string
+	Layers  []Layer
+	Anchors map[string]Point
+
+	cache map[float64]*rendered
+}
+
+type rendered struct {
+	img        *ebiten.Image
+	minX, minY float32 // Sprite coordinates of the image's top-left
+}
+
+// With returns a copy of s wearing the extra layers.
+func (s *Sprite) With(layers ...Layer) *Sprite {
+	out := &Sprite{Name: s.Name, Anchors: s.Anchors}
+	out.Layers = append(append([]Layer{}, s.Layers...), layers...)
+	sort.SliceStable(out.Layers, func(i, j int) bool { return out.Layers[i].Z < out.Layers[j].Z })
+	return out
+}
+
+// Anchor returns a named point, or the origin.
+func (s *Sprite) Anchor(name string) Point {
+	return s.Anchors[name]
+}
+
+// shapes yields every shape in draw order, moved to sprite coordinates.
+func (s *Sprite) shapes(fn func(Shape)) {
+	for _, l := range s.Layers {
+		a := s.Anchor(l.Anchor)
+		for _, sh := range l.Shapes {
+			sh.X += a.X
+			sh.Y += a.Y
+			sh.X2 += a.X
+			sh.Y2 += a.Y
+			fn(sh)
+		}
+	}
+}
+
+// bounds in sprite coordinates, padded a pixel for antialiasing.
+func (s *Sprite) bounds() (minX, minY, maxX, maxY float32) {
+	minX, minY = math.MaxFloat32, math.MaxFloat32
+	maxX, maxY = -math.MaxFloat32, -math.MaxFloat32
+	grow := func(x0, y0, x1, y1 float32) {
+		minX, minY = min(minX, x0), min(minY, y0)
+		maxX, maxY = max(maxX, x1), max(maxY, y1)
+	}
+	s.shapes(func(sh Shape) {
+		switch sh.Kind {
+		case Circle:
+			grow(sh.X-sh.R, sh.Y-sh.R, sh.X+sh.R, sh.Y+sh.R)
+		case Rect:
+			grow(sh.X, sh.Y, sh.X+sh.W, sh.Y+sh.H)
+		case Line:
+			w := sh.Width / 2
+			grow(min(sh.X, sh.X2)-w, min(sh.Y, sh.Y2)-w, max(sh.X, sh.X2)+w, max(sh.Y, sh.Y2)+w)
+		}
+	})
+	if minX > maxX {
+		return 0, 0, 0, 0
+	}
+	return minX - 1, minY - 1, maxX + 1, maxY + 1
+}
+
+// Image renders the sprite at scale, caching the result. It also returns
+// where the sprite's origin lies in the image.
+func (s *Sprite) Image(scale float64) (img *ebiten.Image, originX, originY float64) {
+	r := s.render(scale)
+	return r.img, float64(-r.minX) * scale, float64(-r.minY) * scale
+}
+
+func (s *Sprite) render(scale float64) *rendered {
+	if r, ok := s.cache[scale]; ok {
+		return r
+	}
+	minX, minY, maxX, maxY := s.bounds()
+	k := float32(scale)
+	w := max(int(math.Ceil(float64((maxX-minX)*k))), 1)
+	h := max(int(math.Ceil(float64((maxY-minY)*k))), 1)
+	img := ebiten.NewImage(w, h)
+	s.shapes(func(sh Shape) {
+		x, y := (sh.X-minX)*k, (sh.Y-minY)*k
+		switch sh.Kind {
+		case Circle:
+			vector.FillCircle(img, x, y, sh.R*k, sh.Color, true)
+		case Rect:
+			vector.FillRect(img, x, y, sh.W*k, sh.H*k, sh.Color, true)
+		case Line:
+			vector.StrokeLine(img, x, y, (sh.X2-minX)*k, (sh.Y2-minY)*k, sh.Width*k, sh.Color, true)
+		}
+	})
+
+	if s.cache == nil {
+		s.cache = map[float64]*rendered{}
+	}
+	r := &rendered{img: img, minX: minX, minY: minY}
+	s.cache[scale] = r
+	return r
+}
+
+// DrawOptions tweak Draw; nil means scale 1, facing right.
+type DrawOptions struct {
+	Scale float64 // 0 means 1
+	FlipX bool    // Mirror around the origin
+}
+
+// Draw puts the sprite's origin at x, y on dst.
+func (s *Sprite) Draw(dst *ebiten.Image, x, y float64, opts *DrawOptions) {
+	scale, flip := 1.0, false
+	if opts != nil {
+		if opts.Scale > 0 {
+			scale = opts.Scale
+		}
+		flip = opts.FlipX
+	}
+	r := s.render(scale)
+
+	op := &ebiten.DrawImageOptions{}
+	if flip {
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(x-float64(r.minX)*scale, y+float64(r.minY)*scale)
+	} else {
+		op.GeoM.Translate(x+float64(r.minX)*scale, y+float64(r.minY)*scale)
+	}
+	dst.DrawImage(r.img, op)
+}
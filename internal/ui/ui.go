@@ -0,0 +1,248 @@
+// Package ui is a small immediate-mode widget toolkit for the 320x240
+// retro screens. A scene declares its widgets every Update between Begin
+// and End; each widget handles its own input there and records how to
+// draw itself, and the scene's Draw replays that with UI.Draw.
+//
+// Focus moves with the Up/Down actions (or Tab), and with Left/Right
+// unless the focused widget uses them itself (sliders, toggles). Confirm
+// activates, and the mouse focuses whatever it hovers and activates what
+// it clicks. Working through input.Actions, all of this follows the
+// player's key and gamepad bindings. Holding a direction repeats it.
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"panda/internal/input"
+	"panda/internal/pixeltext"
+)
+
+// Theme colors the widgets.
+type Theme struct {
+	Text   pixeltext.Style
+	Accent color.RGBA // Focus bar, filled tracks
+	Bg     color.RGBA // Text on the focus bar, dialog background
+}
+
+const (
+	rowHeight = 14 // Height of a button or list row
+	gap       = 2  // Between stacked widgets
+	padX      = 4  // Text inset within a widget
+)
+
+// UI holds the state that outlives a frame: focus, the layout cursor and
+// the draw list.
+type UI struct {
+	Actions *input.Actions
+	Keys    *input.Keyboard // Tab, text entry and hotkeys
+	Pointer *input.Pointer
+	Theme   Theme
+	Screen  image.Rectangle // Logical screen; modals centre themselves in it
+
+	focus      int  // Index of the focused widget
+	n          int  // Focusable widgets declared so far this frame
+	lastN      int  // ... and last frame
+	valueFocus bool // The focused widget takes Left/Right itself
+	saved      int  // Focus behind an open modal
+
+	modal, modalPrev bool // A modal was declared this/last frame
+	inModal          bool // Declaring the modal's own widgets
+	modalN           int  // Buttons in the modal
+	blockedN         int  // Widgets the modal blocked this frame
+
+	px, py  int // Pointer last frame, to notice hovering
+	hovered bool
+	tick    int
+
+	x, y, w int  // Layout cursor and column width
+	row     bool // Lay widgets out left to right instead of stacking
+
+	ops, overlay []func(dst *ebiten.Image)
+}
+
+func New(actions *input.Actions, pointer *input.Pointer, screen image.Rectangle) *UI {
+	return &UI{Actions: actions, Keys: actions.Keys, Pointer: pointer, Screen: screen}
+}
+
+// Begin starts a frame's widgets and applies focus navigation.
+func (u *UI) Begin(theme Theme) {
+	u.Theme = theme
+	u.tick++
+	u.n, u.blockedN = 0, 0
+	u.ops = u.ops[:0]
+	u.overlay = u.overlay[:0]
+	u.modalPrev, u.modal = u.modal, false
+
+	x, y := u.Pointer.Position()
+	u.hovered = x != u.px || y != u.py
+	u.px, u.py = x, y
+
+	value := u.valueFocus
+	u.valueFocus = false
+	if u.lastN == 0 {
+		return
+	}
+	k, a := u.Keys, u.Actions
+	tab := k.Repeated(ebiten.KeyTab)
+	next := a.Repeated(input.ActionDown) || (tab && !k.Pressed(ebiten.KeyShift))
+	prev := a.Repeated(input.ActionUp) || (tab && k.Pressed(ebiten.KeyShift))
+	if !value {
+		next = next || a.Repeated(input.ActionRight)
+		prev = prev || a.Repeated(input.ActionLeft)
+	}
+	switch {
+	case next:
+		u.focus = (u.focus + 1) % u.lastN
+	case prev:
+		u.focus = (u.focus + u.lastN - 1) % u.lastN
+	}
+}
+
+// End closes the frame, moving focus into or back out of a modal that
+// just opened or closed.
+func (u *UI) End() {
+	u.lastN = u.n
+	switch {
+	case u.modal && !u.modalPrev:
+		// Its buttons only count from the next frame on
+		u.saved, u.focus = u.focus, 0
+		u.lastN = u.modalN
+	case !u.modal && u.modalPrev:
+		u.focus = u.saved
+		u.lastN = u.blockedN
+	}
+	if u.focus >= u.lastN {
+		u.focus = 0
+	}
+}
+
+// SetFocus focuses the i-th focusable widget, e.g. to start a list on
+// its current selection.
+func (u *UI) SetFocus(i int) {
+	u.focus = i
+}
+
+// Draw renders the widgets declared in the last Update.
+func (u *UI) Draw(dst *ebiten.Image) {
+	for _, op := range u.ops {
+		op(dst)
+	}
+	for _, op := range u.overlay {
+		op(dst)
+	}
+}
+
+// --- Layout ---
+
+// Column stacks the following widgets downwards from x, y, each w wide.
+func (u *UI) Column(x, y, w int) {
+	u.x, u.y, u.w, u.row = x, y, w, false
+}
+
+// Row lines the following widgets up rightwards from x, y, each as wide
+// as its label.
+func (u *UI) Row(x, y int) {
+	u.x, u.y, u.row = x, y, true
+}
+
+// Spacer leaves n pixels of room.
+func (u *UI) Spacer(n int) {
+	if u.row {
+		u.x += n
+	} else {
+		u.y += n
+	}
+}
+
+// next claims the next widget rectangle; width is used in rows.
+func (u *UI) next(width, height int) image.Rectangle {
+	if !u.row {
+		width = u.w
+	}
+	r := image.Rect(u.x, u.y, u.x+width, u.y+height)
+	if u.row {
+		u.x += width + gap*2
+	} else {
+		u.y += height + gap
+	}
+	return r
+}
+
+func (u *UI) draw(op func(dst *ebiten.Image)) {
+	if u.inModal {
+		u.overlay = append(u.overlay, op)
+	} else {
+		u.ops = append(u.ops, op)
+	}
+}
+
+// --- Interaction ---
+
+// interact registers a focusable widget at r. value says it consumes
+// Left/Right while focused.
+func (u *UI) interact(r image.Rectangle, value bool) (focused, clicked bool) {
+	if u.modalPrev && !u.inModal {
+		u.blockedN++
+		return false, false
+	}
+	i := u.n
+	u.n++
+	inside := image.Pt(u.Pointer.Position()).In(r)
+	if inside && (u.hovered || u.Pointer.JustPressed()) {
+		u.focus = i
+	}
+	focused = u.focus == i
+	if focused {
+		u.valueFocus = value
+	}
+	return focused, inside && u.Pointer.JustPressed()
+}
+
+func (u *UI) confirm() bool {
+	return u.Actions.JustPressed(input.ActionConfirm)
+}
+
+func (u *UI) hotkey(keys []ebiten.Key) bool {
+	if u.modalPrev && !u.inModal {
+		return false
+	}
+	for _, k := range keys {
+		if u.Keys.JustPressed(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// --- Drawing Helpers ---
+
+func (u *UI) textStyle(focused bool) pixeltext.Style {
+	st := u.Theme.Text
+	if focused {
+		st.Color, st.Shadow = u.Theme.Bg, nil
+	}
+	return st
+}
+
+func (u *UI) drawFocus(dst *ebiten.Image, r image.Rectangle, focused bool) {
+	if focused {
+		fillRect(dst, r, u.Theme.Accent)
+	}
+}
+
+func fillRect(dst *ebiten.Image, r image.Rectangle, c color.Color) {
+	vector.FillRect(dst, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), c, false)
+}
+
+func strokeRect(dst *ebiten.Image, r image.Rectangle, c color.Color) {
+	vector.StrokeRect(dst, float32(r.Min.X)+0.5, float32(r.Min.Y)+0.5, float32(r.Dx())-1, float32(r.Dy())-1, 1, c, false)
+}
+
+// textY centres a line of text vertically in r.
+func textY(r image.Rectangle) float64 {
+	return float64(r.Min.Y + (r.Dy()-pixeltext.GlyphHeight)/2)
+}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"panda/internal/input"
+	"panda/internal/pixeltext"
+)
+
+// Label is non-interactive text, wrapped to the column width.
+func (u *UI) Label(text string) {
+	u.LabelStyle(text, u.Theme.Text)
+}
+
+// LabelStyle is Label with its own style (e.g. accent-colored titles).
+func (u *UI) LabelStyle(text string, st pixeltext.Style) {
+	if !u.row {
+		st.Width = float64(u.w)
+	}
+	w, h := pixeltext.Measure(text, st)
+	r := u.next(int(w), int(h)-(pixeltext.LineHeight-pixeltext.GlyphHeight))
+	x := float64(r.Min.X)
+	switch st.Align {
+	case pixeltext.Center:
+		x = float64(r.Min.X+r.Max.X) / 2
+	case pixeltext.Right:
+		x = float64(r.Max.X)
+	}
+	u.draw(func(dst *ebiten.Image) {
+		pixeltext.Draw(dst, text, x, float64(r.Min.Y), st)
+	})
+}
+
+// Button reports whether it was activated this tick: Confirm while
+// focused, a click, or one of its hotkeys.
+func (u *UI) Button(label string, hotkeys ...ebiten.Key) bool {
+	r := u.next(int(pixeltext.Width(label))+padX*2, rowHeight)
+	focused, clicked := u.interact(r, false)
+	u.draw(func(dst *ebiten.Image) {
+		u.drawFocus(dst, r, focused)
+		pixeltext.Draw(dst, label, float64(r.Min.X+padX), textY(r), u.textStyle(focused))
+	})
+	return clicked || (focused && u.confirm()) || u.hotkey(hotkeys)
+}
+
+// Toggle flips *v when activated, or with Left/Right while focused. It
+// reports whether *v changed.
+func (u *UI) Toggle(label string, v *bool, hotkeys ...ebiten.Key) bool {
+	r := u.next(int(pixeltext.Width(label+": OFF"))+padX*2, rowHeight)
+	focused, clicked := u.interact(r, true)
+	a := u.Actions
+	changed := clicked || u.hotkey(hotkeys) ||
+		(focused && (u.confirm() || a.JustPressed(input.ActionLeft) || a.JustPressed(input.ActionRight)))
+	if changed {
+		*v = !*v
+	}
+
+	text := label + ": OFF"
+	if *v {
+		text = label + ": ON"
+	}
+	u.draw(func(dst *ebiten.Image) {
+		u.drawFocus(dst, r, focused)
+		pixeltext.Draw(dst, text, float64(r.Min.X+padX), textY(r), u.textStyle(focused))
+	})
+	return changed
+}
+
+// Slider edits *v between lo and hi in steps with Left/Right while
+// focused; clicking sets it from the pointer position. It reports
+// whether *v changed. format renders the value, e.g. "%dm".
+func (u *UI) Slider(label string, v *int, lo, hi, step int, format string) bool {
+	const trackW = 60
+	labelW := int(pixeltext.Width(label)) + padX*2
+	valueW := int(pixeltext.Width(fmt.Sprintf(format, hi))) + padX
+	r := u.next(labelW+trackW+valueW+padX, rowHeight)
+	track := image.Rect(r.Min.X+labelW, r.Min.Y+rowHeight/2-2, r.Min.X+labelW+trackW, r.Min.Y+rowHeight/2+2)
+	if !u.row {
+		track = track.Add(image.Pt(r.Dx()-labelW-trackW-valueW-padX, 0))
+	}
+	focused, clicked := u.interact(r, true)
+
+	old, next := *v, *v
+	switch {
+	case clicked:
+		px, _ := u.Pointer.Position()
+		if px >= track.Min.X-2 && px <= track.Max.X+2 {
+			f := float64(px-track.Min.X) / float64(track.Dx())
+			next = lo + int(f*float64(hi-lo)/float64(step)+0.5)*step
+		}
+	case focused && u.Actions.Repeated(input.ActionLeft):
+		next -= step
+	case focused && u.Actions.Repeated(input.ActionRight):
+		next += step
+	}
+	// Only clamp on edits, so an out-of-range value loaded from disk
+	// isn't rewritten just by showing it
+	if next != old {
+		*v = min(max(next, lo), hi)
+	}
+
+	val := *v
+	frac := min(max(float64(val-lo)/float64(max(hi-lo, 1)), 0), 1)
+	u.draw(func(dst *ebiten.Image) {
+		u.drawFocus(dst, r, focused)
+		st := u.textStyle(focused)
+		pixeltext.Draw(dst, label, float64(r.Min.X+padX), textY(r), st)
+		c := u.Theme.Accent
+		if focused {
+			c = u.Theme.Bg
+		}
+		strokeRect(dst, track, c)
+		fill := track
+		fill.Max.X = fill.Min.X + int(float64(track.Dx())*frac)
+		fillRect(dst, fill, c)
+		pixeltext.Draw(dst, fmt.Sprintf(format, val), float64(track.Max.X+padX), textY(r), st)
+	})
+	return *v != old
+}
+
+// List shows items as focusable rows. Focusing a row selects it into
+// *selected; the result is true when the selected row is activated.
+func (u *UI) List(items []string, selected *int) bool {
+	activated := false
+	for i, item := range items {
+		r := u.next(int(pixeltext.Width(item))+padX*2, rowHeight)
+		focused, clicked := u.interact(r, false)
+		if focused && !u.modalPrev {
+			*selected = i
+		}
+		if clicked || (focused && u.confirm()) {
+			activated = true
+		}
+		marked := i == *selected
+		u.draw(func(dst *ebiten.Image) {
+			u.drawFocus(dst, r, focused)
+			prefix := "  "
+			if marked {
+				prefix = "> "
+			}
+			pixeltext.Draw(dst, prefix+item, float64(r.Min.X+padX), textY(r), u.textStyle(focused))
+		})
+	}
+	return activated
+}
+
+// TextInput edits *s while focused: typed characters are appended up to
+// maxLen runes and Backspace deletes. It reports whether *s changed.
+func (u *UI) TextInput(label string, s *string, maxLen int) bool {
+	const boxW = 96
+	labelW := int(pixeltext.Width(label)) + padX*2
+	r := u.next(labelW+boxW, rowHeight)
+	box := image.Rect(r.Max.X-boxW, r.Min.Y, r.Max.X, r.Max.Y)
+	focused, _ := u.interact(r, true)
+
+	old := *s
+	if focused {
+		runes := []rune(*s)
+		for _, c := range u.Keys.Chars() {
+			if unicode.IsPrint(c) && len(runes) < maxLen {
+				runes = append(runes, c)
+			}
+		}
+		if u.Keys.JustPressed(ebiten.KeyBackspace) && len(runes) > 0 {
+			runes = runes[:len(runes)-1]
+		}
+		*s = string(runes)
+	}
+
+	text, caret := *s, focused && u.tick/20%2 == 0
+	u.draw(func(dst *ebiten.Image) {
+		pixeltext.Draw(dst, label, float64(r.Min.X+padX), textY(r), u.Theme.Text)
+		border := u.Theme.Text.Color
+		if focused {
+			border = u.Theme.Accent
+		}
+		strokeRect(dst, box, border)
+		pixeltext.Draw(dst, text, float64(box.Min.X+3), textY(box), u.Theme.Text.WithWidth(0))
+		if caret {
+			x := box.Min.X + 3 + int(pixeltext.Width(text))
+			fillRect(dst, image.Rect(x, box.Min.Y+2, x+1, box.Max.Y-2), u.Theme.Accent)
+		}
+	})
+	return *s != old
+}
+
+// Progress is a non-interactive bar, frac full (0-1), with an optional
+// label centred under it.
+func (u *UI) Progress(label string, frac float64) {
+	r := u.next(120, 8)
+	if label != "" {
+		u.Spacer(pixeltext.LineHeight)
+	}
+	frac = min(max(frac, 0), 1)
+	u.draw(func(dst *ebiten.Image) {
+		strokeRect(dst, r, u.Theme.Accent)
+		fill := r.Inset(2)
+		fill.Max.X = fill.Min.X + int(float64(fill.Dx())*frac)
+		fillRect(dst, fill, u.Theme.Accent)
+		if label != "" {
+			pixeltext.Draw(dst, label, float64(r.Min.X+r.Max.X)/2, float64(r.Max.Y+2), u.Theme.Text.WithAlign(pixeltext.Center))
+		}
+	})
+}
+
+// Modal opens a dialog over everything else for as long as the scene
+// keeps declaring it. Other widgets ignore input while it's up. It
+// returns the index of the button chosen this tick, or -1.
+func (u *UI) Modal(title, message string, buttons ...string) int {
+	const w = 200
+	st := u.Theme.Text.WithWidth(w - 16)
+	_, th := pixeltext.Measure(message, st)
+	h := 20 + int(th) + 8 + rowHeight + 8
+	c := u.Screen.Min.Add(u.Screen.Max).Div(2)
+	panel := image.Rect(c.X-w/2, c.Y-h/2, c.X+w/2, c.Y+h/2)
+
+	u.modal = true
+	u.modalN = len(buttons)
+	u.inModal = true
+	defer func() { u.inModal = false }()
+
+	u.draw(func(dst *ebiten.Image) {
+		fillRect(dst, u.Screen, color.RGBA{0, 0, 0, 0x90})
+		fillRect(dst, panel, u.Theme.Bg)
+		strokeRect(dst, panel, u.Theme.Accent)
+		pixeltext.Draw(dst, title, float64(panel.Min.X+8), float64(panel.Min.Y+4), u.Theme.Text.WithColor(u.Theme.Accent))
+		pixeltext.Draw(dst, message, float64(panel.Min.X+8), float64(panel.Min.Y+20), st)
+	})
+
+	// Buttons share the bottom row; they only react once the modal has
+	// been up for a frame, so the key that opened it can't also close it
+	bw := (w - 8*(len(buttons)+1)) / max(len(buttons), 1)
+	chosen := -1
+	for i, b := range buttons {
+		r := image.Rect(panel.Min.X+8+i*(bw+8), panel.Max.Y-8-rowHeight, panel.Min.X+8+i*(bw+8)+bw, panel.Max.Y-8)
+		focused := false
+		if u.modalPrev {
+			var clicked bool
+			focused, clicked = u.interact(r, false)
+			if clicked || (focused && u.confirm()) {
+				chosen = i
+			}
+		}
+		u.draw(func(dst *ebiten.Image) {
+			u.drawFocus(dst, r, focused)
+			strokeRect(dst, r, u.Theme.Accent)
+			pixeltext.Draw(dst, b, float64(r.Min.X+r.Max.X)/2, textY(r), u.textStyle(focused).WithAlign(pixeltext.Center))
+		})
+	}
+	return chosen
+}
@@ -1,30 +1,83 @@
 package main
 
 import (
+    "flag"
     "log"
+    "os"
+
     "github.com/hajimehoshi/ebiten/v2"
+
+    "panda/internal/assets"
+    "panda/internal/gamemode"
+    "panda/internal/paths"
 )
 
 // Screen Constants (Retro 4:3)
 const (
-    ScreenWidth  = 320
-    ScreenHeight = 240
+    ScreenWidth  = gamemode.ScreenWidth
+    ScreenHeight = gamemode.ScreenHeight
     WindowTitle  = "Panda Focus"
 )
 
 func main() {
-    // 1. Window Setup
+    dataDir := flag.String("data-dir", "", "keep settings, stats and history in this directory")
+    flag.Usage = usage
+    flag.Parse()
+    log.SetFlags(0)
+    log.SetPrefix("panda: ")
+
+    // 1. Save Locations
+    p, err := paths.Resolve(*dataDir)
+    if err != nil {
+        log.Fatal(err)
+    }
+    // Older builds saved into the working directory; copy those files over
+    // the first time
+    if wd, err := os.Getwd(); err == nil {
+        copied, err := p.MigrateLegacy(wd, legacySave)
+        for _, f := range copied {
+            log.Printf("copied save file to %s", f)
+        }
+        if err != nil {
+            log.Print(err)
+        }
+    }
+
+    // 2. Subcommand (defaults to opening the window)
+    if err := runCommand(p, flag.Args()); err != nil {
+        log.Fatal(err)
+    }
+}
+
+// runOptions are the `panda run` flags.
+type runOptions struct {
+    APIAddr  string // Serve the control API here if set
+    AssetDir string // Dev mode: hot-reload assets from this directory
+}
+
+// runGame opens the window and blocks until it is closed.
+func runGame(p paths.Paths, opts runOptions) error {
+    // Window Setup
     ebiten.SetWindowSize(ScreenWidth*3, ScreenHeight*3) // 3x Scale for desktop
     ebiten.SetWindowTitle(WindowTitle)
     ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
-	ebiten.SetFilter(ebiten.FilterNearest) // Disables smoothing/blurring
-	
 
-    // 2. Initialize Game
-    game := NewGame()
+    if opts.AssetDir != "" {
+        if err := assets.UseDir(opts.AssetDir); err != nil {
+            return err
+        }
+        log.Printf("dev mode: watching assets in %s", opts.AssetDir)
+    }
 
-    // 3. Run Loop
-    if err := ebiten.RunGame(game); err != nil {
-        log.Fatal(err)
+    // Initialize Game & Run Loop
+    game := NewGame(p)
+    game.watchAssets = opts.AssetDir != ""
+    if opts.APIAddr != "" {
+        if err := game.ServeAPI(opts.APIAddr); err != nil {
+            return err
+        }
     }
-}
\ No newline at end of file
+    err := ebiten.RunGame(game)
+    game.SaveStats()
+    return err
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"panda/internal/gamemode"
+	"panda/internal/history"
+	"panda/internal/paths"
+	"panda/internal/pomodoro"
+	"panda/internal/save"
+)
+
+// Schema versions of the save files. Bump one and add a migration from
+// the old number whenever the saved shape changes.
+const (
+	statsVersion    = 1
+	settingsVersion = 1
+	saveBackups     = 3
+)
+
+// Unversioned (v0) stats files need no changes to read as v1.
+func newStatsFile(path string) *save.File {
+	return &save.File{Path: path, Version: statsVersion, Backups: saveBackups}
+}
+
+func newSettingsFile(path string) *save.File {
+	return &save.File{
+		Path:    path,
+		Version: settingsVersion,
+		Backups: saveBackups,
+		Migrations: map[int]save.Migration{
+			// v0 predates the Pomodoro cycle config
+			0: func(doc map[string]any) error {
+				if _, ok := doc["focus"]; !ok {
+					doc["focus"] = pomodoro.DefaultConfig()
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// loadStats reads the stats file and starts fresh daily counters if it
+// was last saved on an earlier day. Errors are logged rather than fatal:
+// losing a save should never stop the panda from starting.
+func loadStats(f *save.File, now time.Time) gamemode.GameStats {
+	return readStats(f.Load, now)
+}
+
+// peekStats is loadStats for commands that only report: it leaves the
+// save files exactly as they are.
+func peekStats(f *save.File, now time.Time) gamemode.GameStats {
+	return readStats(f.Read, now)
+}
+
+func readStats(load func(any) (save.Result, error), now time.Time) gamemode.GameStats {
+	var s gamemode.GameStats
+	res, err := load(&s)
+	report("stats", res, err)
+
+	today := now.Format("2006-01-02")
+	if s.LastLoginDate != today {
+		s.TodayPlayTimeSec = 0
+		s.PacmanWinsToday = 0
+		s.LastLoginDate = today
+	}
+	return s
+}
+
+// loadSettings reads the settings file, falling back to defaults. found
+// is false only when no settings file (or backup) exists at all.
+func loadSettings(f *save.File) (s gamemode.AppSettings, found bool) {
+	return readSettings(f.Load)
+}
+
+// peekSettings is loadSettings without touching the files.
+func peekSettings(f *save.File) gamemode.AppSettings {
+	s, _ := readSettings(f.Read)
+	return s
+}
+
+func readSettings(load func(any) (save.Result, error)) (s gamemode.AppSettings, found bool) {
+	res, err := load(&s)
+	report("settings", res, err)
+	if err != nil {
+		return gamemode.DefaultSettings(), !errors.Is(err, save.ErrNotFound)
+	}
+	return s, true
+}
+
+// legacySave reports whether a file found by the legacy migration is
+// really one of ours: it has to decode as our schema with nothing left
+// over, so a settings.json belonging to whatever project panda was
+// started in stays put.
+func legacySave(name string, data []byte) bool {
+	switch name {
+	case paths.SettingsName:
+		var s struct {
+			gamemode.AppSettings
+			Version int `json:"version"`
+		}
+		return strictJSON(data, &s) && len(s.Profiles) > 0
+	case paths.StatsName:
+		var s struct {
+			gamemode.GameStats
+			Version int `json:"version"`
+		}
+		return strictJSON(data, &s) && s.LastLoginDate != ""
+	case paths.HistoryName:
+		line, _, _ := bytes.Cut(data, []byte("\n"))
+		var e history.Entry
+		return strictJSON(line, &e) && !e.Start.IsZero()
+	}
+	return false
+}
+
+// strictJSON decodes data into v, failing on fields v doesn't have.
+func strictJSON(data []byte, v any) bool {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v) == nil
+}
+
+func report(what string, res save.Result, err error) {
+	switch {
+	case errors.Is(err, save.ErrNotFound):
+	case err != nil:
+		log.Printf("%s: %v", what, err)
+	case res.Recovered:
+		log.Printf("%s: recovered from %s", what, res.Source)
+	}
+}